@@ -0,0 +1,121 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+)
+
+// Horizon Condition Types used by API objects.
+const (
+	// HorizonConfigOverwriteReadyCondition Status=True condition which indicates
+	// that every DefaultConfigOverwrite key has been placed in the Pod
+	HorizonConfigOverwriteReadyCondition condition.Type = "HorizonConfigOverwriteReady"
+
+	// HorizonAutoscalingReadyCondition Status=True condition which indicates
+	// that the HorizontalPodAutoscaler is able to scale the Deployment
+	HorizonAutoscalingReadyCondition condition.Type = "HorizonAutoscalingReady"
+
+	// HorizonRedisReadyCondition Status=True condition which indicates that
+	// the Redis instance storing the sessions is ready
+	HorizonRedisReadyCondition condition.Type = "HorizonRedisReady"
+
+	// HorizonIngressReadyCondition Status=True condition which indicates that
+	// the Route or Ingress exposing the dashboard has a host name
+	HorizonIngressReadyCondition condition.Type = "HorizonIngressReady"
+
+	// HorizonHTTPRouteReadyCondition Status=True condition which indicates
+	// that the HTTPRoute exposing the dashboard is Accepted by its Gateway and
+	// its references are resolved
+	HorizonHTTPRouteReadyCondition condition.Type = "HorizonHTTPRouteReady"
+
+	// HorizonPodDisruptionBudgetReadyCondition Status=True condition which
+	// indicates that the PodDisruptionBudget of the horizon pods is up to date
+	HorizonPodDisruptionBudgetReadyCondition condition.Type = "HorizonPodDisruptionBudgetReady"
+)
+
+// Horizon Condition messages
+const (
+	// HorizonConfigOverwriteReadyInitMessage -
+	HorizonConfigOverwriteReadyInitMessage = "DefaultConfigOverwrite not processed"
+
+	// HorizonConfigOverwriteReadyMessage -
+	HorizonConfigOverwriteReadyMessage = "DefaultConfigOverwrite files placed"
+
+	// HorizonConfigOverwriteReadyErrorMessage -
+	HorizonConfigOverwriteReadyErrorMessage = "DefaultConfigOverwrite keys with no known destination: %s"
+
+	// HorizonAutoscalingReadyInitMessage -
+	HorizonAutoscalingReadyInitMessage = "HorizontalPodAutoscaler not started"
+
+	// HorizonAutoscalingReadyMessage -
+	HorizonAutoscalingReadyMessage = "HorizontalPodAutoscaler ready, %d current replicas, %d desired replicas"
+
+	// HorizonAutoscalingReadyErrorMessage -
+	HorizonAutoscalingReadyErrorMessage = "HorizontalPodAutoscaler error occurred %s"
+
+	// HorizonRedisReadyInitMessage -
+	HorizonRedisReadyInitMessage = "Redis not started"
+
+	// HorizonRedisReadyMessage -
+	HorizonRedisReadyMessage = "Redis ready"
+
+	// HorizonRedisReadyWaitingMessage -
+	HorizonRedisReadyWaitingMessage = "Redis %s not yet ready"
+
+	// HorizonRedisReadyErrorMessage -
+	HorizonRedisReadyErrorMessage = "Redis error occurred %s"
+
+	// HorizonIngressReadyInitMessage -
+	HorizonIngressReadyInitMessage = "Ingress not started"
+
+	// HorizonIngressReadyMessage -
+	HorizonIngressReadyMessage = "%s %s exposes %s"
+
+	// HorizonIngressReadyWaitingMessage -
+	HorizonIngressReadyWaitingMessage = "%s %s has no host name yet"
+
+	// HorizonIngressReadyErrorMessage -
+	HorizonIngressReadyErrorMessage = "Ingress error occurred %s"
+
+	// HorizonHTTPRouteReadyInitMessage -
+	HorizonHTTPRouteReadyInitMessage = "HTTPRoute not started"
+
+	// HorizonHTTPRouteReadyMessage -
+	HorizonHTTPRouteReadyMessage = "HTTPRoute accepted by Gateway %s"
+
+	// HorizonHTTPRouteReadyWaitingMessage -
+	HorizonHTTPRouteReadyWaitingMessage = "HTTPRoute %s condition of Gateway %s is %s: %s"
+
+	// HorizonHTTPRouteReadyAddressMessage -
+	HorizonHTTPRouteReadyAddressMessage = "Gateway %s has no address yet"
+
+	// HorizonHTTPRouteReadyErrorMessage -
+	HorizonHTTPRouteReadyErrorMessage = "HTTPRoute error occurred %s"
+
+	// HorizonPodDisruptionBudgetReadyInitMessage -
+	HorizonPodDisruptionBudgetReadyInitMessage = "PodDisruptionBudget not started"
+
+	// HorizonPodDisruptionBudgetReadyMessage -
+	HorizonPodDisruptionBudgetReadyMessage = "PodDisruptionBudget ready"
+
+	// HorizonPodDisruptionBudgetNotRequiredMessage -
+	HorizonPodDisruptionBudgetNotRequiredMessage = "PodDisruptionBudget not required with %d replicas"
+
+	// HorizonPodDisruptionBudgetReadyErrorMessage -
+	HorizonPodDisruptionBudgetReadyErrorMessage = "PodDisruptionBudget error occurred %s"
+)
@@ -17,6 +17,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 	"context"
@@ -27,9 +28,30 @@ import (
 	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
 	appsv1 "k8s.io/api/apps/v1"
 	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 
 )
 
+const (
+	// HorizonRoleLabel - label consumers set on a Horizon CR to tell which
+	// dashboard they are interested in when more than one exists in a
+	// namespace
+	HorizonRoleLabel = "horizon.openstack.org/role"
+	// HorizonRolePrimary - HorizonRoleLabel value of the dashboard other
+	// services link to by default
+	HorizonRolePrimary = "primary"
+)
+
+// ErrMultipleHorizonsFound is returned when a lookup matches more than one
+// Horizon object
+var ErrMultipleHorizonsFound = errors.New("more than one Horizon object found")
+
+// HorizonRoleSelector - returns the label selector matching the Horizon
+// instances with the given role
+func HorizonRoleSelector(role string) map[string]string {
+	return map[string]string{HorizonRoleLabel: role}
+}
+
 // HorizonEndpointChangedPredicate - primary purpose is to return true if
 // the Horizon Status.Endpoints has changed (e.g. it has been set)
 // In addition also returns true if it gets deleted (it helps to react to
@@ -59,7 +81,11 @@ var HorizonEndpointChangedPredicate = predicate.Funcs{
 
 // GetHorizon - Get Horizon CR in the namespace passed as input. It lists the
 // Items deployed in the current namespace and return the Horizon object if
-// it exists, else an error
+// it exists, else an error. When several of them are deployed the primary
+// one is returned. The webhook rejects a second primary Horizon, but two of
+// them created concurrently can both be admitted: the tie is then resolved
+// deterministically by returning the oldest one, the one with the lowest
+// name when they were created in the same second
 func GetHorizon(
 	ctx context.Context,
 	h *helper.Helper,
@@ -77,7 +103,23 @@ func GetHorizon(
 	}
 
 	if len(horizonList.Items) > 1 {
-		return nil, fmt.Errorf("More then one Horizon object found in namespace %s", namespace)
+		// Prefer the primary dashboard when several of them are deployed
+		primary := labels.SelectorFromSet(HorizonRoleSelector(HorizonRolePrimary))
+		var match *Horizon
+		for i := range horizonList.Items {
+			item := &horizonList.Items[i]
+			if !primary.Matches(labels.Set(item.GetLabels())) {
+				continue
+			}
+			if match == nil || item.CreationTimestamp.Before(&match.CreationTimestamp) ||
+				(item.CreationTimestamp.Equal(&match.CreationTimestamp) && item.Name < match.Name) {
+				match = item
+			}
+		}
+		if match != nil {
+			return match, nil
+		}
+		return nil, fmt.Errorf("%w in namespace %s", ErrMultipleHorizonsFound, namespace)
 	}
 
 	if len(horizonList.Items) == 0 {
@@ -88,3 +130,81 @@ func GetHorizon(
 	}
 	return &horizonList.Items[0], nil
 }
+
+// GetHorizonByName - Get the Horizon CR with the given name in the namespace
+// passed as input
+func GetHorizonByName(
+	ctx context.Context,
+	h *helper.Helper,
+	name string,
+	namespace string,
+) (*Horizon, error) {
+	horizon := &Horizon{}
+	err := h.GetClient().Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, horizon)
+	if err != nil {
+		return nil, err
+	}
+	return horizon, nil
+}
+
+// GetHorizonBySelector - Get the Horizon CR matching the label selector in the
+// namespace passed as input, e.g. HorizonRoleSelector(HorizonRolePrimary).
+// It returns an error if no object or more than one object match
+func GetHorizonBySelector(
+	ctx context.Context,
+	h *helper.Helper,
+	namespace string,
+	selector map[string]string,
+) (*Horizon, error) {
+	horizonList := &HorizonList{}
+
+	listOpts := []client.ListOption{
+		client.InNamespace(namespace),
+		client.MatchingLabels(selector),
+	}
+
+	err := h.GetClient().List(ctx, horizonList, listOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(horizonList.Items) > 1 {
+		return nil, fmt.Errorf("%w in namespace %s matching %v",
+			ErrMultipleHorizonsFound, namespace, labels.Set(selector))
+	}
+
+	if len(horizonList.Items) == 0 {
+		return nil, k8s_errors.NewNotFound(
+			appsv1.Resource("Horizon"),
+			fmt.Sprintf("No Horizon object found in namespace %s matching %v",
+				namespace, labels.Set(selector)),
+		)
+	}
+	return &horizonList.Items[0], nil
+}
+
+// HorizonEndpointChangedPredicateForName - returns a predicate behaving like
+// HorizonEndpointChangedPredicate, limited to the Horizon instance with the
+// given name. Services linking to a specific dashboard use it so they do not
+// reconcile when another instance in the namespace changes
+func HorizonEndpointChangedPredicateForName(name string) predicate.Predicate {
+	return predicate.And[client.Object](
+		predicate.NewPredicateFuncs(func(o client.Object) bool {
+			return o.GetName() == name
+		}),
+		HorizonEndpointChangedPredicate,
+	)
+}
+
+// HorizonEndpointChangedPredicateForSelector - returns a predicate behaving
+// like HorizonEndpointChangedPredicate, limited to the Horizon instances
+// matching the label selector, e.g. HorizonRoleSelector(HorizonRolePrimary)
+func HorizonEndpointChangedPredicateForSelector(selector map[string]string) predicate.Predicate {
+	sel := labels.SelectorFromSet(selector)
+	return predicate.And[client.Object](
+		predicate.NewPredicateFuncs(func(o client.Object) bool {
+			return sel.Matches(labels.Set(o.GetLabels()))
+		}),
+		HorizonEndpointChangedPredicate,
+	)
+}
@@ -17,8 +17,18 @@ limitations under the License.
 package v1beta1
 
 import (
+	"errors"
 	"fmt"
+	"maps"
+	"net/url"
+	"regexp"
+	"regexp/syntax"
+	"slices"
 	"strings"
+	"time"
+	// embed the IANA time zone database to validate the TimeZone setting
+	// regardless of the content of the operator image
+	_ "time/tzdata"
 
 	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/service"
@@ -26,10 +36,13 @@ import (
 	"github.com/openstack-k8s-operators/lib-common/modules/common/tls"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
 	"github.com/openstack-k8s-operators/lib-common/modules/storage"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 const (
@@ -41,8 +54,151 @@ const (
 	HorizonCustomThemeSetting = "/etc/openstack-dashboard/local_settings.d"
 	// HorizonThemeExtraVolType -
 	HorizonThemeExtraVolType = "theme"
+	// SSOCredentialsChoice - WEBSSO_CHOICES key of the Keystone credentials login
+	SSOCredentialsChoice = "credentials"
+	// SSOProtocolOpenID - Keystone federation protocol used by OpenID Connect
+	SSOProtocolOpenID = "openid"
+	// SSOClientSecretSelector - key of the SSO CredentialsSecret holding the client secret
+	SSOClientSecretSelector = "ClientSecret"
+	// SSOCryptoPassphraseSelector - key of the SSO CredentialsSecret holding the
+	// passphrase used by mod_auth_openidc to encrypt its state
+	SSOCryptoPassphraseSelector = "CryptoPassphrase"
+	// DbSyncHash - status hash entry of the database migration job
+	DbSyncHash = "dbsync"
+	// SecretKeyRotationAnnotation - annotation triggering a rotation of
+	// SECRET_KEY whenever its value changes
+	SecretKeyRotationAnnotation = "horizon.openstack.org/rotate-secret-key"
+	// MinSecretKeyRotationInterval - shortest interval between two automatic
+	// rotations of SECRET_KEY, a rotation invalidates the sessions signed with
+	// the key before the previous one
+	MinSecretKeyRotationInterval = time.Hour
+	// AdditionalHostnamesAnnotation - annotation of the Service, Route,
+	// Ingress and HTTPRoute listing spec.additionalHostnames, comma separated
+	AdditionalHostnamesAnnotation = "horizon.openstack.org/additional-hostnames"
+	// DefaultWebRoot - URL path the dashboard is served under by default
+	DefaultWebRoot = "/dashboard"
 )
 
+// HorizonPluginMode - how the operator decides whether a dashboard plugin is
+// enabled
+// +kubebuilder:validation:Enum=auto;enabled;disabled
+type HorizonPluginMode string
+
+const (
+	// PluginModeAuto - the plugin is enabled when the KeystoneService of the
+	// related OpenStack service exists
+	PluginModeAuto HorizonPluginMode = "auto"
+	// PluginModeEnabled - the plugin is always enabled
+	PluginModeEnabled HorizonPluginMode = "enabled"
+	// PluginModeDisabled - the plugin is always disabled
+	PluginModeDisabled HorizonPluginMode = "disabled"
+)
+
+// HorizonSessionKeyStrategy - how the prefix of the cached session keys is
+// computed
+// +kubebuilder:validation:Enum=configHash;stable;generation
+type HorizonSessionKeyStrategy string
+
+const (
+	// SessionKeyConfigHash - the sessions are dropped on any change of the
+	// horizon inputs (CONFIG_HASH)
+	SessionKeyConfigHash HorizonSessionKeyStrategy = "configHash"
+	// SessionKeyStable - the sessions are dropped when the authentication
+	// settings change, they survive a SECRET_KEY rotation
+	SessionKeyStable HorizonSessionKeyStrategy = "stable"
+	// SessionKeyGeneration - the sessions are dropped when the generation
+	// counter is bumped
+	SessionKeyGeneration HorizonSessionKeyStrategy = "generation"
+)
+
+// HorizonSessionBackend - where the user sessions are stored
+// +kubebuilder:validation:Enum=memcached;redis;signed_cookies;database
+type HorizonSessionBackend string
+
+const (
+	// SessionBackendMemcached - the sessions are stored in the Memcached
+	// instance referenced by MemcachedInstance
+	SessionBackendMemcached HorizonSessionBackend = "memcached"
+	// SessionBackendRedis - the sessions are stored in the Redis instance
+	// referenced by RedisInstance
+	SessionBackendRedis HorizonSessionBackend = "redis"
+	// SessionBackendSignedCookies - the sessions are stored in cookies signed
+	// with SECRET_KEY
+	SessionBackendSignedCookies HorizonSessionBackend = "signed_cookies"
+	// SessionBackendDatabase - the sessions are stored in the MariaDB
+	// database described by Database
+	SessionBackendDatabase HorizonSessionBackend = "database"
+)
+
+// HorizonProfile - audience of the dashboard
+// +kubebuilder:validation:Enum=tenant;admin;full
+type HorizonProfile string
+
+const (
+	// ProfileTenant - the dashboard serves the project users, the admin
+	// dashboard is hidden
+	ProfileTenant HorizonProfile = "tenant"
+	// ProfileAdmin - the dashboard serves the cloud operators, it is only
+	// exposed through the internal Service and uses the internal endpoints
+	// of the service catalog
+	ProfileAdmin HorizonProfile = "admin"
+	// ProfileFull - the dashboard serves all users with every panel
+	ProfileFull HorizonProfile = "full"
+)
+
+// HorizonEndpointInterface - interface of the OpenStack endpoints
+// +kubebuilder:validation:Enum=public;internal
+type HorizonEndpointInterface string
+
+const (
+	// EndpointInterfacePublic - the public endpoints
+	EndpointInterfacePublic HorizonEndpointInterface = "public"
+	// EndpointInterfaceInternal - the internal endpoints
+	EndpointInterfaceInternal HorizonEndpointInterface = "internal"
+)
+
+// HorizonTLSTermination - where the TLS connections of the Route or Ingress
+// are terminated
+// +kubebuilder:validation:Enum=edge;reencrypt;passthrough
+type HorizonTLSTermination string
+
+const (
+	// TLSTerminationEdge - TLS is terminated by the router, which forwards
+	// plain HTTP to the pods
+	TLSTerminationEdge HorizonTLSTermination = "edge"
+	// TLSTerminationReencrypt - TLS is terminated by the router, which opens
+	// a new TLS connection to the pods
+	TLSTerminationReencrypt HorizonTLSTermination = "reencrypt"
+	// TLSTerminationPassthrough - TLS is terminated by the pods
+	TLSTerminationPassthrough HorizonTLSTermination = "passthrough"
+)
+
+// DashboardPlugins - dashboard plugins that can be enabled in the horizon
+// container, named after the KeystoneService of the related OpenStack service
+var DashboardPlugins = []string{
+	"cloudkitty",
+	"designate",
+	"heat",
+	"ironic",
+	"manila",
+	"octavia",
+	"watcher",
+}
+
+// webRootRegexp - absolute URL path, with an optional trailing slash, whose
+// segments don't need to be escaped in httpd.conf and local_settings.py
+var webRootRegexp = regexp.MustCompile(`^(/[A-Za-z0-9._~-]+)+/?$`)
+
+// PolicyServices - service types supported by the dashboard POLICY_FILES
+var PolicyServices = []string{
+	"compute",
+	"identity",
+	"image",
+	"network",
+	"orchestration",
+	"volume",
+}
+
 // HorizonSpec defines the desired state of Horizon
 type HorizonSpec struct {
 	// +kubebuilder:validation:Required
@@ -59,9 +215,10 @@ type HorizonSpecCore struct {
 	NodeSelector *map[string]string `json:"nodeSelector,omitempty"`
 
 	// +kubebuilder:validation:Optional
-	// ConfigOverwrite - interface to overwrite default config files like e.g. logging.conf or policy.json.
-	// But can also be used to add additional files. Those get added to the service config dir in /etc/<service> .
-	// TODO: -> implement
+	// DefaultConfigOverwrite - additional files placed in the horizon container according to their name:
+	// <service>_policy.{json,yaml,yml} files go to /etc/openstack-dashboard (POLICY_FILES_PATH),
+	// *.py files go to /etc/openstack-dashboard/local_settings.d and *.conf files go to
+	// /etc/httpd/conf_custom. Other keys are reported in the HorizonConfigOverwriteReady condition.
 	DefaultConfigOverwrite map[string]string `json:"defaultConfigOverwrite,omitempty"`
 
 	// +kubebuilder:validation:Optional
@@ -86,9 +243,15 @@ type HorizonSpecCore struct {
 	Replicas *int32 `json:"replicas"`
 
 	// +kubebuilder:validation:Required
-	// Secret containing OpenStack password information for Horizon Secret Key
+	// Secret containing OpenStack password information for Horizon Secret Key,
+	// read from the passwordSelectors.secretKey key
 	Secret string `json:"secret"`
 
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default={secretKey: HorizonSecretKey}
+	// PasswordSelectors - Selectors to identify the keys of Secret
+	PasswordSelectors PasswordSelector `json:"passwordSelectors"`
+
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:default="# add your customization here"
 	// CustomServiceConfig - customize the service config using this parameter to change service defaults,
@@ -96,11 +259,28 @@ type HorizonSpecCore struct {
 	// to /etc/openstack-dashboard/local_settings.d directory as 9999_custom_settings.py file.
 	CustomServiceConfig string `json:"customServiceConfig"`
 
-	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Optional
 	// +kubebuilder:default=memcached
-	// Memcached instance name.
+	// Memcached instance name, used by the memcached session backend.
 	MemcachedInstance string `json:"memcachedInstance"`
 
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=memcached
+	// SessionBackend - where the user sessions are stored: in the memcached
+	// cache, in the redis cache, in signed cookies, with no server side
+	// storage, or in a MariaDB database
+	SessionBackend HorizonSessionBackend `json:"sessionBackend,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// RedisInstance - Redis instance name, required by the redis session
+	// backend
+	RedisInstance string `json:"redisInstance,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Database - MariaDB database storing the sessions, required by the
+	// database session backend
+	Database *HorizonDatabaseSpec `json:"database,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:default=false
 	// PreserveJobs - do not delete jobs after they finished e.g. to check logs
@@ -118,12 +298,483 @@ type HorizonSpecCore struct {
 	// TopologyRef to apply the Topology defined by the associated CR referenced
 	// by name
 	TopologyRef *topologyv1.TopoRef `json:"topologyRef,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// SSO - WebSSO (Keystone federation) parameters used to render the login
+	// choices in local_settings.py and the related httpd directives
+	SSO *HorizonSSOSpec `json:"sso,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Settings - commonly tuned dashboard settings rendered by the operator in
+	// a dedicated local_settings.d snippet. CustomServiceConfig is loaded
+	// afterwards and can still override them
+	Settings *HorizonSettings `json:"settings,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Policies - policy files used by the dashboard to decide which actions
+	// are displayed, indexed by service type (identity, compute, volume,
+	// network, image, orchestration). They should match the policies enforced
+	// by the OpenStack services
+	Policies map[string]HorizonPolicySource `json:"policies,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Plugins - overrides the state of the dashboard plugins (cloudkitty,
+	// designate, heat, ironic, manila, octavia, watcher). By default (auto) a
+	// plugin is enabled when the KeystoneService of the related OpenStack
+	// service exists
+	Plugins map[string]HorizonPluginMode `json:"plugins,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Autoscaling - when set, the operator manages an HorizontalPodAutoscaler
+	// scaling the horizon Deployment, and Replicas is ignored
+	Autoscaling *HorizonAutoscalingSpec `json:"autoscaling,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// PodDisruptionBudget - overrides the PodDisruptionBudget managed for the
+	// horizon pods when more than one replica is requested. By default at
+	// most one pod can be disrupted at a time
+	PodDisruptionBudget *HorizonPodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// RolloutStrategy - rolling update parameters of the horizon Deployment,
+	// the Kubernetes defaults are used when not set
+	RolloutStrategy *HorizonRolloutStrategy `json:"rolloutStrategy,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// SessionKey - controls the prefix of the session keys stored in
+	// memcached, hence when the user sessions are invalidated. By default
+	// they are invalidated by any configuration change
+	SessionKey *HorizonSessionKeySpec `json:"sessionKey,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// SecretKeyRotation - rotates the SECRET_KEY generated by the operator.
+	// The previous key is kept in SECRET_KEY_FALLBACKS so that the existing
+	// sessions and CSRF tokens stay valid during the rollout. A rotation can
+	// also be requested by changing the value of the
+	// horizon.openstack.org/rotate-secret-key annotation
+	SecretKeyRotation *HorizonSecretKeyRotationSpec `json:"secretKeyRotation,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=full
+	// Profile - audience of the dashboard: tenant hides the admin dashboard,
+	// admin serves the cloud operators through the internal Service only and
+	// uses the internal endpoints of the service catalog, full serves both
+	Profile HorizonProfile `json:"profile,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// EndpointInterfaces - interfaces of the Keystone endpoint and of the
+	// service catalog endpoints used by the dashboard
+	EndpointInterfaces *HorizonEndpointInterfacesSpec `json:"endpointInterfaces,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Regions - regions listed in AVAILABLE_REGIONS, in the order of the
+	// region selector of the login page. When set, only the extraMounts
+	// without region or with the region of one of the entries are mounted
+	Regions []HorizonRegionSpec `json:"regions,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Ingress - when set, the operator exposes the dashboard through a Route
+	// on OpenShift, or an Ingress otherwise, and reports its URL in
+	// Status.Endpoint. By default openstack-operator creates the Route
+	Ingress *HorizonIngressSpec `json:"ingress,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=/dashboard
+	// WebRoot - URL path the dashboard is served under, e.g. / to serve it at
+	// the root of the host name. It drives WEBROOT, LOGIN_URL and LOGOUT_URL,
+	// the httpd aliases, the redirect of the root path and the probes
+	WebRoot string `json:"webRoot,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// AdditionalHostnames - host names the dashboard is reached through besides
+	// the host of Status.Endpoint, e.g. a second DNS name or a vanity domain.
+	// They are added to ALLOWED_HOSTS and CSRF_TRUSTED_ORIGINS, to the Route,
+	// Ingress or HTTPRoute managed by the operator and to the
+	// horizon.openstack.org/additional-hostnames annotation of the Service
+	AdditionalHostnames []string `json:"additionalHostnames,omitempty"`
+}
+
+// HorizonIngressSpec defines the Route or Ingress exposing the dashboard
+type HorizonIngressSpec struct {
+	// +kubebuilder:validation:Optional
+	// Hostname - host name of the Route or Ingress. The OpenShift router
+	// generates one for a Route when not set
+	Hostname string `json:"hostname,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// TLSTermination - TLS termination mode: edge when TLS is disabled on
+	// the pods, reencrypt or passthrough when it is enabled. Defaults to
+	// edge, or reencrypt when TLS is enabled
+	TLSTermination HorizonTLSTermination `json:"tlsTermination,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// TLSSecretName - Secret holding the certificate exposed by the Route or
+	// Ingress (tls.crt, tls.key and optionally ca.crt). The default
+	// certificate of the router is used when not set
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// IngressClassName - IngressClass of the Ingress, ignored for a Route
+	IngressClassName *string `json:"ingressClassName,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Annotations - annotations of the Route or Ingress, e.g. to configure
+	// the ingress controller
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// HorizonRegionSpec defines a region listed in AVAILABLE_REGIONS
+type HorizonRegionSpec struct {
+	// +kubebuilder:validation:Required
+	// Name - name of the region displayed in the region selector
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Optional
+	// KeystoneURL - URL of the Keystone of the region. The Keystone used by
+	// the dashboard is taken when neither KeystoneURL nor KeystoneAPI is set
+	KeystoneURL string `json:"keystoneURL,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// KeystoneAPI - KeystoneAPI of the region, possibly in another
+	// namespace. Its endpoint of the EndpointInterfaces.Keystone interface
+	// is used
+	KeystoneAPI *HorizonKeystoneAPIRef `json:"keystoneAPI,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// CaBundleSecretName - Secret, in the namespace of the Horizon CR,
+	// holding the CA bundle (tls-ca-bundle.pem) of the Keystone of the
+	// region. It is added to the CA bundle of the pod
+	CaBundleSecretName string `json:"caBundleSecretName,omitempty"`
+}
+
+// HorizonKeystoneAPIRef references a KeystoneAPI
+type HorizonKeystoneAPIRef struct {
+	// +kubebuilder:validation:Required
+	// Name - name of the KeystoneAPI
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Optional
+	// Namespace - namespace of the KeystoneAPI, the namespace of the Horizon
+	// CR when not set
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// HorizonEndpointInterfacesSpec defines which endpoints the dashboard uses to
+// reach Keystone and the OpenStack services
+type HorizonEndpointInterfacesSpec struct {
+	// +kubebuilder:validation:Optional
+	// Keystone - interface of the Keystone endpoint set in
+	// OPENSTACK_KEYSTONE_URL, internal when not set
+	Keystone HorizonEndpointInterface `json:"keystone,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Catalog - interface of the service catalog endpoints
+	// (OPENSTACK_ENDPOINT_TYPE). When not set, internal for the admin profile
+	// and public otherwise
+	Catalog HorizonEndpointInterface `json:"catalog,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// SecondaryCatalog - interface of the service catalog endpoints used when
+	// an endpoint of the Catalog interface is missing
+	// (SECONDARY_ENDPOINT_TYPE). It must differ from Catalog
+	SecondaryCatalog HorizonEndpointInterface `json:"secondaryCatalog,omitempty"`
+}
+
+// HorizonSecretKeyRotationSpec defines the rotation policy of SECRET_KEY
+type HorizonSecretKeyRotationSpec struct {
+	// +kubebuilder:validation:Optional
+	// Interval - time between two automatic rotations, at least 1h. No
+	// automatic rotation happens when unset
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
+
+// PasswordSelector to identify the keys of the Secret
+type PasswordSelector struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=HorizonSecretKey
+	// SecretKey - key of the Secret holding the Django SECRET_KEY. The
+	// operator generates the key when the Secret doesn't contain it
+	SecretKey string `json:"secretKey"`
+}
+
+// HorizonDatabaseSpec defines the MariaDB database created through
+// mariadb-operator to store the sessions
+type HorizonDatabaseSpec struct {
+	// +kubebuilder:validation:Required
+	// DatabaseInstance - name of the Galera instance hosting the database
+	DatabaseInstance string `json:"databaseInstance"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=horizon
+	// DatabaseAccount - name of the MariaDBAccount used to connect to the
+	// database
+	DatabaseAccount string `json:"databaseAccount"`
+}
+
+// HorizonSessionKeySpec defines how the prefix of the cached session keys is
+// computed
+type HorizonSessionKeySpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=configHash
+	// Strategy - configHash drops the sessions on any configuration change,
+	// stable only when the authentication settings change, and generation
+	// only when Generation is bumped
+	Strategy HorizonSessionKeyStrategy `json:"strategy,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// Generation - counter to bump to drop the sessions with the generation
+	// strategy
+	Generation int32 `json:"generation,omitempty"`
+}
+
+// HorizonRolloutStrategy defines how the horizon pods are replaced when the
+// Deployment is updated
+type HorizonRolloutStrategy struct {
+	// +kubebuilder:validation:Optional
+	// MaxSurge - number or percentage of pods that can be created above the
+	// desired number of replicas during a rollout
+	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// MaxUnavailable - number or percentage of pods that can be unavailable
+	// during a rollout
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// MinReadySeconds - number of seconds a new pod must be ready before it
+	// is considered available
+	MinReadySeconds *int32 `json:"minReadySeconds,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// ProgressDeadlineSeconds - number of seconds the rollout can take to make
+	// progress before it is reported as stuck in the DeploymentReady condition
+	ProgressDeadlineSeconds *int32 `json:"progressDeadlineSeconds,omitempty"`
+}
+
+// HorizonPodDisruptionBudgetSpec defines the PodDisruptionBudget of the
+// horizon pods, only one of MinAvailable and MaxUnavailable can be set
+type HorizonPodDisruptionBudgetSpec struct {
+	// +kubebuilder:validation:Optional
+	// MinAvailable - number or percentage of horizon pods that must remain
+	// available during a voluntary disruption
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// MaxUnavailable - number or percentage of horizon pods that can be
+	// unavailable during a voluntary disruption
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// HorizonAutoscalingSpec defines the HorizontalPodAutoscaler managed for the
+// horizon Deployment
+type HorizonAutoscalingSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=1
+	// MinReplicas - lower limit for the number of horizon replicas
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	// MaxReplicas - upper limit for the number of horizon replicas
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// TargetCPUUtilizationPercentage - target average CPU utilization of the
+	// horizon pods, as a percentage of the requested CPU. It defaults to 80
+	// when no target is set
+	TargetCPUUtilizationPercentage *int32 `json:"targetCPUUtilizationPercentage,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// TargetMemoryUtilizationPercentage - target average memory utilization
+	// of the horizon pods, as a percentage of the requested memory
+	TargetMemoryUtilizationPercentage *int32 `json:"targetMemoryUtilizationPercentage,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Behavior - scaling behavior of the HorizontalPodAutoscaler in the up and
+	// down directions
+	Behavior *autoscalingv2.HorizontalPodAutoscalerBehavior `json:"behavior,omitempty"`
+}
+
+// HorizonPolicySource defines the ConfigMap holding the policy file of an
+// OpenStack service
+type HorizonPolicySource struct {
+	// +kubebuilder:validation:Required
+	// ConfigMapName - name of the ConfigMap holding the policy file
+	ConfigMapName string `json:"configMapName"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=policy.yaml
+	// Key - ConfigMap key holding the policy file, in YAML or JSON format
+	Key string `json:"key"`
+}
+
+// HorizonSettings defines the typed dashboard settings managed by the operator
+type HorizonSettings struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=60
+	// SessionTimeout - SESSION_TIMEOUT in seconds
+	SessionTimeout *int32 `json:"sessionTimeout,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// PasswordValidator - regex and help text used to validate the user
+	// passwords set through the dashboard
+	PasswordValidator *HorizonPasswordValidator `json:"passwordValidator,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=direct;legacy;off
+	// ImagesUploadMode - HORIZON_IMAGES_UPLOAD_MODE
+	ImagesUploadMode string `json:"imagesUploadMode,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// TimeZone - TIME_ZONE, it must be a valid IANA time zone name
+	TimeZone string `json:"timeZone,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// DefaultTheme - DEFAULT_THEME
+	DefaultTheme string `json:"defaultTheme,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// KeystoneMultiDomainSupport - OPENSTACK_KEYSTONE_MULTIDOMAIN_SUPPORT
+	KeystoneMultiDomainSupport *bool `json:"keystoneMultiDomainSupport,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// KeystoneDefaultDomain - OPENSTACK_KEYSTONE_DEFAULT_DOMAIN
+	KeystoneDefaultDomain string `json:"keystoneDefaultDomain,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=AUTO;VNC;SPICE;RDP;SERIAL;MKS
+	// ConsoleType - CONSOLE_TYPE
+	ConsoleType string `json:"consoleType,omitempty"`
+}
+
+// HorizonPasswordValidator defines the password_validator HORIZON_CONFIG entry
+type HorizonPasswordValidator struct {
+	// +kubebuilder:validation:Required
+	// Regex - Python regular expression the password must match
+	Regex string `json:"regex"`
+
+	// +kubebuilder:validation:Optional
+	// HelpText - message displayed when the password does not match Regex
+	HelpText string `json:"helpText,omitempty"`
+}
+
+// HorizonSSOSpec defines the WebSSO configuration of the dashboard
+type HorizonSSOSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=credentials
+	// InitialChoice - the login choice selected by default. It can be either
+	// "credentials" (Keystone credentials) or the name of one of the
+	// IdentityProviders
+	InitialChoice string `json:"initialChoice"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="Keystone Credentials"
+	// CredentialsDisplayName - label of the Keystone credentials login choice
+	CredentialsDisplayName string `json:"credentialsDisplayName"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// IdentityProviders - list of federated identity providers offered in the
+	// login form
+	IdentityProviders []HorizonSSOIdentityProvider `json:"identityProviders"`
+}
+
+// HorizonSSOIdentityProvider defines a federated identity provider exposed in
+// the dashboard login form
+type HorizonSSOIdentityProvider struct {
+	// +kubebuilder:validation:Required
+	// Name - key of the WEBSSO_CHOICES and WEBSSO_IDP_MAPPING entries
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Optional
+	// DisplayName - label displayed in the login form, defaults to Name
+	DisplayName string `json:"displayName,omitempty"`
+
+	// +kubebuilder:validation:Required
+	// IdentityProvider - ID of the identity provider registered in Keystone
+	IdentityProvider string `json:"identityProvider"`
+
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=openid;saml2;mapped
+	// Protocol - Keystone federation protocol associated to the identity
+	// provider
+	Protocol string `json:"protocol"`
+
+	// +kubebuilder:validation:Optional
+	// ProviderMetadataURL - OpenID Connect discovery document URL. When set,
+	// the mod_auth_openidc directives are rendered in httpd.conf (openid
+	// protocol only)
+	ProviderMetadataURL string `json:"providerMetadataURL,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// ClientID - OpenID Connect client ID registered in the identity provider
+	ClientID string `json:"clientID,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// CredentialsSecret - name of the Secret holding the client credentials of
+	// the identity provider. The Secret is expected to provide the
+	// ClientSecret and CryptoPassphrase keys
+	CredentialsSecret string `json:"credentialsSecret,omitempty"`
 }
 
 // HorizionOverrideSpec to override the generated manifest of several child resources.
 type HorizionOverrideSpec struct {
 	// Override configuration for the Service created to serve traffic to the cluster.
 	Service *service.RoutedOverrideSpec `json:"service,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Gateway - when set, the operator exposes the dashboard through a Gateway
+	// API HTTPRoute attached to the referenced Gateway, and reports its URL in
+	// Status.Endpoint
+	Gateway *HorizonGatewaySpec `json:"gateway,omitempty"`
+}
+
+// HorizonGatewaySpec defines the HTTPRoute exposing the dashboard through a
+// Gateway
+type HorizonGatewaySpec struct {
+	// +kubebuilder:validation:Required
+	// ParentRef - Gateway the HTTPRoute is attached to
+	ParentRef HorizonGatewayParentRef `json:"parentRef"`
+
+	// +kubebuilder:validation:Optional
+	// Hostnames - host names matched by the HTTPRoute, the first one is used
+	// in Status.Endpoint. The HTTPRoute matches the host names of the
+	// listener of the Gateway when not set
+	Hostnames []string `json:"hostnames,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=https
+	// +kubebuilder:validation:Enum=http;https
+	// Scheme - scheme of the listener of the Gateway, used in Status.Endpoint
+	Scheme string `json:"scheme,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Annotations - annotations of the HTTPRoute
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// HorizonGatewayParentRef defines the Gateway an HTTPRoute is attached to
+type HorizonGatewayParentRef struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// Name - name of the Gateway
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Optional
+	// Namespace - namespace of the Gateway, the namespace of the Horizon CR
+	// when not set
+	Namespace string `json:"namespace,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// SectionName - listener of the Gateway the HTTPRoute is attached to, all
+	// the listeners accepting it when not set
+	SectionName string `json:"sectionName,omitempty"`
 }
 
 // HorizonStatus defines the observed state of Horizon
@@ -151,11 +802,31 @@ type HorizonStatus struct {
 
 	// LastAppliedTopology - the last applied Topology
 	LastAppliedTopology *topologyv1.TopoRef `json:"lastAppliedTopology,omitempty"`
+
+	// EnabledPlugins - dashboard plugins enabled in the horizon container,
+	// resolved from spec.plugins and the existing KeystoneServices
+	EnabledPlugins []string `json:"enabledPlugins,omitempty"`
+
+	// SecretKeyLastRotated - last time SECRET_KEY was generated
+	SecretKeyLastRotated *metav1.Time `json:"secretKeyLastRotated,omitempty"`
+
+	// SecretKeyRotationTrigger - value of the
+	// horizon.openstack.org/rotate-secret-key annotation handled by the last
+	// rotation
+	SecretKeyRotationTrigger string `json:"secretKeyRotationTrigger,omitempty"`
+
+	// DatabaseHostname - hostname of the database storing the sessions
+	DatabaseHostname string `json:"databaseHostname,omitempty"`
+
+	// Hostnames - host names accepted by the dashboard: the host of Endpoint
+	// and spec.additionalHostnames
+	Hostnames []string `json:"hostnames,omitempty"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:printcolumn:name="NetworkAttachments",type="string",JSONPath=".status.networkAttachments",description="NetworkAttachments"
+//+kubebuilder:printcolumn:name="Plugins",type="string",JSONPath=".status.enabledPlugins",description="Enabled dashboard plugins"
 //+kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[0].status",description="Status"
 //+kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.conditions[0].message",description="Message"
 
@@ -273,3 +944,527 @@ func (instance *HorizonSpecCore) ValidateTopology(
 		*basePath.Child("topologyRef"), namespace)...)
 	return allErrs
 }
+
+// GetOIDCProvider - returns the identity provider used to render the
+// mod_auth_openidc configuration, nil if none of them defines a
+// ProviderMetadataURL
+func (instance *HorizonSSOSpec) GetOIDCProvider() *HorizonSSOIdentityProvider {
+	for i, idp := range instance.IdentityProviders {
+		if idp.Protocol == SSOProtocolOpenID && idp.ProviderMetadataURL != "" {
+			return &instance.IdentityProviders[i]
+		}
+	}
+	return nil
+}
+
+// ValidateSSO -
+func (instance *HorizonSpecCore) ValidateSSO(basePath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if instance.SSO == nil {
+		return allErrs
+	}
+	ssoPath := basePath.Child("sso")
+	idpPath := ssoPath.Child("identityProviders")
+
+	choices := map[string]bool{SSOCredentialsChoice: true}
+	oidcProviders := 0
+	for i, idp := range instance.SSO.IdentityProviders {
+		if choices[idp.Name] {
+			allErrs = append(allErrs, field.Duplicate(idpPath.Index(i).Child("name"), idp.Name))
+		}
+		choices[idp.Name] = true
+
+		if idp.ProviderMetadataURL == "" {
+			continue
+		}
+		if idp.Protocol != SSOProtocolOpenID {
+			allErrs = append(allErrs, field.Invalid(
+				idpPath.Index(i).Child("providerMetadataURL"), idp.ProviderMetadataURL,
+				"providerMetadataURL is only supported with the openid protocol"))
+		}
+		// both values are rendered as quoted httpd directive arguments
+		if u, err := url.Parse(idp.ProviderMetadataURL); err != nil || u.Scheme != "https" || u.Host == "" ||
+			strings.ContainsAny(idp.ProviderMetadataURL, "\" \t\r\n") {
+			allErrs = append(allErrs, field.Invalid(
+				idpPath.Index(i).Child("providerMetadataURL"), idp.ProviderMetadataURL,
+				"providerMetadataURL must be an https URL"))
+		}
+		if strings.ContainsAny(idp.ClientID, "\"\\\r\n") {
+			allErrs = append(allErrs, field.Invalid(
+				idpPath.Index(i).Child("clientID"), idp.ClientID,
+				"clientID can't contain quotes, backslashes or line breaks"))
+		}
+		if idp.ClientID == "" || idp.CredentialsSecret == "" {
+			allErrs = append(allErrs, field.Required(
+				idpPath.Index(i).Child("credentialsSecret"),
+				"clientID and credentialsSecret are required when providerMetadataURL is set"))
+		}
+		oidcProviders++
+		// mod_auth_openidc can only be configured against a single provider
+		// in the same VirtualHost
+		if oidcProviders > 1 {
+			allErrs = append(allErrs, field.Forbidden(
+				idpPath.Index(i).Child("providerMetadataURL"),
+				"only one identity provider can define providerMetadataURL"))
+		}
+	}
+	if instance.SSO.InitialChoice != "" && !choices[instance.SSO.InitialChoice] {
+		allErrs = append(allErrs, field.NotSupported(
+			ssoPath.Child("initialChoice"), instance.SSO.InitialChoice, slices.Sorted(maps.Keys(choices))))
+	}
+	return allErrs
+}
+
+// ValidateSettings -
+func (instance *HorizonSpecCore) ValidateSettings(basePath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if instance.Settings == nil {
+		return allErrs
+	}
+	settingsPath := basePath.Child("settings")
+
+	if tz := instance.Settings.TimeZone; tz != "" {
+		if _, err := time.LoadLocation(tz); err != nil {
+			allErrs = append(allErrs, field.Invalid(
+				settingsPath.Child("timeZone"), tz, "unknown time zone"))
+		}
+	}
+
+	if pv := instance.Settings.PasswordValidator; pv != nil {
+		if err := checkPasswordRegex(pv.Regex); err != nil {
+			allErrs = append(allErrs, field.Invalid(
+				settingsPath.Child("passwordValidator", "regex"), pv.Regex, err.Error()))
+		}
+		// Without an help text users are not told why their password is rejected
+		if pv.HelpText == "" {
+			allErrs = append(allErrs, field.Required(
+				settingsPath.Child("passwordValidator", "helpText"),
+				"helpText is required when a password regex is set"))
+		}
+	}
+	return allErrs
+}
+
+// passwordRegexErrors - the RE2 errors that Python re reports as well. The
+// regex is evaluated by Python, so the constructs RE2 doesn't support, like
+// lookaheads, are accepted
+var passwordRegexErrors = []syntax.ErrorCode{
+	syntax.ErrMissingBracket,
+	syntax.ErrMissingParen,
+	syntax.ErrUnexpectedParen,
+	syntax.ErrInvalidCharRange,
+	syntax.ErrTrailingBackslash,
+	syntax.ErrMissingRepeatArgument,
+}
+
+// checkPasswordRegex - returns the error of a password regex which can't be
+// compiled by Python re either
+func checkPasswordRegex(regex string) error {
+	_, err := regexp.Compile(regex)
+	var syntaxErr *syntax.Error
+	if errors.As(err, &syntaxErr) && slices.Contains(passwordRegexErrors, syntaxErr.Code) {
+		return err
+	}
+	return nil
+}
+
+// ValidateAutoscaling -
+func (instance *HorizonSpecCore) ValidateAutoscaling(basePath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	as := instance.Autoscaling
+	if as == nil {
+		return allErrs
+	}
+	if as.MinReplicas != nil && *as.MinReplicas > as.MaxReplicas {
+		allErrs = append(allErrs, field.Invalid(
+			basePath.Child("autoscaling", "minReplicas"), *as.MinReplicas,
+			"minReplicas must be lower than or equal to maxReplicas"))
+	}
+	return allErrs
+}
+
+// ValidatePodDisruptionBudget -
+func (instance *HorizonSpecCore) ValidatePodDisruptionBudget(basePath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	pdb := instance.PodDisruptionBudget
+	if pdb == nil {
+		return allErrs
+	}
+	pdbPath := basePath.Child("podDisruptionBudget")
+	if pdb.MinAvailable != nil && pdb.MaxUnavailable != nil {
+		allErrs = append(allErrs, field.Forbidden(
+			pdbPath.Child("maxUnavailable"),
+			"minAvailable and maxUnavailable are mutually exclusive"))
+	}
+	// A PodDisruptionBudget which never allows an eviction blocks the drain
+	// of the nodes
+	if isZeroIntOrPercent(pdb.MaxUnavailable) {
+		allErrs = append(allErrs, field.Invalid(
+			pdbPath.Child("maxUnavailable"), pdb.MaxUnavailable.String(),
+			"maxUnavailable may not be 0, no horizon pod could be evicted"))
+	}
+	// the lowest number of replicas, the PodDisruptionBudget is only managed
+	// with more than one replica
+	replicas := int32(1)
+	if instance.Autoscaling != nil {
+		if instance.Autoscaling.MinReplicas != nil {
+			replicas = *instance.Autoscaling.MinReplicas
+		}
+	} else if instance.Replicas != nil {
+		replicas = *instance.Replicas
+	}
+	if minAvailable := pdb.MinAvailable; minAvailable != nil && replicas > 1 &&
+		((minAvailable.Type == intstr.Int && minAvailable.IntVal >= replicas) ||
+			(minAvailable.Type == intstr.String && minAvailable.StrVal == "100%")) {
+		allErrs = append(allErrs, field.Invalid(
+			pdbPath.Child("minAvailable"), minAvailable.String(),
+			fmt.Sprintf("minAvailable must be lower than the %d replicas, no horizon pod could be evicted", replicas)))
+	}
+	return allErrs
+}
+
+// ValidateRolloutStrategy -
+func (instance *HorizonSpecCore) ValidateRolloutStrategy(basePath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	rs := instance.RolloutStrategy
+	if rs == nil {
+		return allErrs
+	}
+	rsPath := basePath.Child("rolloutStrategy")
+	if isZeroIntOrPercent(rs.MaxSurge) && isZeroIntOrPercent(rs.MaxUnavailable) {
+		allErrs = append(allErrs, field.Invalid(
+			rsPath.Child("maxUnavailable"), rs.MaxUnavailable.String(),
+			"maxUnavailable may not be 0 when maxSurge is 0"))
+	}
+	var minReadySeconds int32
+	if rs.MinReadySeconds != nil {
+		minReadySeconds = *rs.MinReadySeconds
+	}
+	if rs.ProgressDeadlineSeconds != nil && *rs.ProgressDeadlineSeconds <= minReadySeconds {
+		allErrs = append(allErrs, field.Invalid(
+			rsPath.Child("progressDeadlineSeconds"), *rs.ProgressDeadlineSeconds,
+			"progressDeadlineSeconds must be greater than minReadySeconds"))
+	}
+	return allErrs
+}
+
+// isZeroIntOrPercent - returns true when an IntOrString is set to 0 or 0%
+func isZeroIntOrPercent(v *intstr.IntOrString) bool {
+	if v == nil {
+		return false
+	}
+	if v.Type == intstr.Int {
+		return v.IntVal == 0
+	}
+	return v.StrVal == "0%"
+}
+
+// GetSessionBackend - returns the session backend, memcached when not set
+func (instance *HorizonSpecCore) GetSessionBackend() HorizonSessionBackend {
+	if instance.SessionBackend == "" {
+		return SessionBackendMemcached
+	}
+	return instance.SessionBackend
+}
+
+// GetProfile - returns the profile of the dashboard, full when not set
+func (instance *HorizonSpecCore) GetProfile() HorizonProfile {
+	if instance.Profile == "" {
+		return ProfileFull
+	}
+	return instance.Profile
+}
+
+// GetWebRoot - returns the URL path the dashboard is served under, without
+// trailing slash, an empty string when the dashboard is served at the root
+// of the host name. It is /dashboard when not set
+func (instance *HorizonSpecCore) GetWebRoot() string {
+	if instance.WebRoot == "" {
+		return DefaultWebRoot
+	}
+	return strings.TrimSuffix(instance.WebRoot, "/")
+}
+
+// GetKeystoneInterface - returns the interface of the Keystone endpoint,
+// internal when not set
+func (instance *HorizonSpecCore) GetKeystoneInterface() HorizonEndpointInterface {
+	if instance.EndpointInterfaces == nil || instance.EndpointInterfaces.Keystone == "" {
+		return EndpointInterfaceInternal
+	}
+	return instance.EndpointInterfaces.Keystone
+}
+
+// GetCatalogInterface - returns the interface of the service catalog
+// endpoints, internal for the admin profile and public otherwise when not set
+func (instance *HorizonSpecCore) GetCatalogInterface() HorizonEndpointInterface {
+	if instance.EndpointInterfaces != nil && instance.EndpointInterfaces.Catalog != "" {
+		return instance.EndpointInterfaces.Catalog
+	}
+	if instance.GetProfile() == ProfileAdmin {
+		return EndpointInterfaceInternal
+	}
+	return EndpointInterfacePublic
+}
+
+// GetSecondaryCatalogInterface - returns the fallback interface of the
+// service catalog endpoints, an empty string when not set
+func (instance *HorizonSpecCore) GetSecondaryCatalogInterface() HorizonEndpointInterface {
+	if instance.EndpointInterfaces == nil {
+		return ""
+	}
+	return instance.EndpointInterfaces.SecondaryCatalog
+}
+
+// ValidateEndpointInterfaces -
+func (instance *HorizonSpecCore) ValidateEndpointInterfaces(basePath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if instance.EndpointInterfaces == nil {
+		return allErrs
+	}
+	path := basePath.Child("endpointInterfaces")
+	supported := []string{string(EndpointInterfacePublic), string(EndpointInterfaceInternal)}
+	for _, f := range []struct {
+		name  string
+		value HorizonEndpointInterface
+	}{
+		{"keystone", instance.EndpointInterfaces.Keystone},
+		{"catalog", instance.EndpointInterfaces.Catalog},
+		{"secondaryCatalog", instance.EndpointInterfaces.SecondaryCatalog},
+	} {
+		if f.value != "" && !slices.Contains(supported, string(f.value)) {
+			allErrs = append(allErrs, field.NotSupported(path.Child(f.name), f.value, supported))
+		}
+	}
+	if secondary := instance.GetSecondaryCatalogInterface(); secondary != "" &&
+		secondary == instance.GetCatalogInterface() {
+		allErrs = append(allErrs, field.Invalid(
+			path.Child("secondaryCatalog"), secondary,
+			fmt.Sprintf("must differ from the %s catalog interface", instance.GetCatalogInterface())))
+	}
+	return allErrs
+}
+
+// GetRegionNames - returns the names of the regions listed in Regions
+func (instance *HorizonSpecCore) GetRegionNames() []string {
+	names := []string{}
+	for _, region := range instance.Regions {
+		names = append(names, region.Name)
+	}
+	return names
+}
+
+// ValidateRegions -
+func (instance *HorizonSpecCore) ValidateRegions(basePath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	names := map[string]bool{}
+	for i, region := range instance.Regions {
+		path := basePath.Child("regions").Index(i)
+		if names[region.Name] {
+			allErrs = append(allErrs, field.Duplicate(path.Child("name"), region.Name))
+		}
+		names[region.Name] = true
+
+		if region.KeystoneURL != "" && region.KeystoneAPI != nil {
+			allErrs = append(allErrs, field.Forbidden(
+				path.Child("keystoneAPI"), "keystoneURL and keystoneAPI are mutually exclusive"))
+		}
+		if region.KeystoneURL != "" {
+			u, err := url.Parse(region.KeystoneURL)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				allErrs = append(allErrs, field.Invalid(
+					path.Child("keystoneURL"), region.KeystoneURL, "must be an http or https URL"))
+			}
+		}
+	}
+	return allErrs
+}
+
+// GetIngressTLSTermination - returns the TLS termination mode of the Route
+// or Ingress, edge or reencrypt depending on the TLS of the pods when not set
+func (instance *HorizonSpecCore) GetIngressTLSTermination() HorizonTLSTermination {
+	if instance.Ingress != nil && instance.Ingress.TLSTermination != "" {
+		return instance.Ingress.TLSTermination
+	}
+	if instance.TLS.Enabled() {
+		return TLSTerminationReencrypt
+	}
+	return TLSTerminationEdge
+}
+
+// ValidateIngress -
+func (instance *HorizonSpecCore) ValidateIngress(basePath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if instance.Ingress == nil {
+		return allErrs
+	}
+	path := basePath.Child("ingress")
+	if instance.GetProfile() == ProfileAdmin {
+		allErrs = append(allErrs, field.Forbidden(
+			path, "the admin profile is only exposed through the internal Service"))
+	}
+	if instance.Ingress.Hostname != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(instance.Ingress.Hostname) {
+			allErrs = append(allErrs, field.Invalid(path.Child("hostname"), instance.Ingress.Hostname, msg))
+		}
+	}
+	switch termination := instance.GetIngressTLSTermination(); {
+	case termination == TLSTerminationEdge && instance.TLS.Enabled():
+		allErrs = append(allErrs, field.Invalid(
+			path.Child("tlsTermination"), termination,
+			"edge termination forwards plain HTTP, which the pods don't accept when TLS is enabled"))
+	case termination != TLSTerminationEdge && !instance.TLS.Enabled():
+		allErrs = append(allErrs, field.Invalid(
+			path.Child("tlsTermination"), termination,
+			"reencrypt and passthrough terminations require TLS to be enabled on the pods"))
+	case termination == TLSTerminationPassthrough && !routeAPIServed:
+		allErrs = append(allErrs, field.Invalid(
+			path.Child("tlsTermination"), termination,
+			"passthrough termination requires an OpenShift Route, the cluster doesn't serve the route.openshift.io API"))
+	}
+	return allErrs
+}
+
+// ValidateAdditionalHostnames -
+func (instance *HorizonSpecCore) ValidateAdditionalHostnames(basePath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	seen := map[string]bool{}
+	for i, hostname := range instance.AdditionalHostnames {
+		path := basePath.Child("additionalHostnames").Index(i)
+		if seen[hostname] {
+			allErrs = append(allErrs, field.Duplicate(path, hostname))
+		}
+		seen[hostname] = true
+		for _, msg := range validation.IsDNS1123Subdomain(hostname) {
+			allErrs = append(allErrs, field.Invalid(path, hostname, msg))
+		}
+		if instance.Ingress != nil && hostname == instance.Ingress.Hostname {
+			allErrs = append(allErrs, field.Invalid(path, hostname, "is already the host name of the ingress"))
+		}
+	}
+	return allErrs
+}
+
+// ValidateWebRoot -
+func (instance *HorizonSpecCore) ValidateWebRoot(basePath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if instance.WebRoot == "" || instance.WebRoot == "/" {
+		return allErrs
+	}
+	if !webRootRegexp.MatchString(instance.WebRoot) {
+		allErrs = append(allErrs, field.Invalid(
+			basePath.Child("webRoot"), instance.WebRoot,
+			"must be / or an absolute URL path made of letters, digits, '.', '_', '~' and '-' segments"))
+	}
+	return allErrs
+}
+
+// ValidateGateway -
+func (instance *HorizonSpecCore) ValidateGateway(basePath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if instance.Override.Gateway == nil {
+		return allErrs
+	}
+	path := basePath.Child("override", "gateway")
+	if instance.Ingress != nil {
+		allErrs = append(allErrs, field.Forbidden(
+			path, "ingress and override.gateway are mutually exclusive"))
+	}
+	if instance.GetProfile() == ProfileAdmin {
+		allErrs = append(allErrs, field.Forbidden(
+			path, "the admin profile is only exposed through the internal Service"))
+	}
+	// the HTTPRoute forwards plain HTTP, reaching pods serving TLS would
+	// require a BackendTLSPolicy
+	if instance.TLS.Enabled() {
+		allErrs = append(allErrs, field.Forbidden(
+			path, "override.gateway requires TLS to be disabled on the pods"))
+	}
+	seen := map[string]bool{}
+	for i, hostname := range instance.Override.Gateway.Hostnames {
+		hostPath := path.Child("hostnames").Index(i)
+		if seen[hostname] {
+			allErrs = append(allErrs, field.Duplicate(hostPath, hostname))
+		}
+		seen[hostname] = true
+		// Gateway API hostnames may start with a wildcard label
+		for _, msg := range validation.IsDNS1123Subdomain(strings.TrimPrefix(hostname, "*.")) {
+			allErrs = append(allErrs, field.Invalid(hostPath, hostname, msg))
+		}
+	}
+	return allErrs
+}
+
+// ValidateSessionBackend -
+func (instance *HorizonSpecCore) ValidateSessionBackend(basePath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	switch instance.GetSessionBackend() {
+	case SessionBackendMemcached:
+		if instance.MemcachedInstance == "" {
+			allErrs = append(allErrs, field.Required(
+				basePath.Child("memcachedInstance"),
+				"memcachedInstance is required by the memcached session backend"))
+		}
+	case SessionBackendRedis:
+		if instance.RedisInstance == "" {
+			allErrs = append(allErrs, field.Required(
+				basePath.Child("redisInstance"),
+				"redisInstance is required by the redis session backend"))
+		}
+	case SessionBackendDatabase:
+		if instance.Database == nil {
+			allErrs = append(allErrs, field.Required(
+				basePath.Child("database"),
+				"database is required by the database session backend"))
+		}
+	}
+	return allErrs
+}
+
+// ValidatePasswordSelectors -
+func (instance *HorizonSpecCore) ValidatePasswordSelectors(basePath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	path := basePath.Child("passwordSelectors", "secretKey")
+	for _, msg := range validation.IsConfigMapKey(instance.PasswordSelectors.SecretKey) {
+		allErrs = append(allErrs, field.Invalid(path, instance.PasswordSelectors.SecretKey, msg))
+	}
+	return allErrs
+}
+
+// ValidateSecretKeyRotation -
+func (instance *HorizonSpecCore) ValidateSecretKeyRotation(basePath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if instance.SecretKeyRotation == nil || instance.SecretKeyRotation.Interval == nil {
+		return allErrs
+	}
+	if interval := instance.SecretKeyRotation.Interval.Duration; interval < MinSecretKeyRotationInterval {
+		allErrs = append(allErrs, field.Invalid(
+			basePath.Child("secretKeyRotation", "interval"),
+			interval.String(),
+			fmt.Sprintf("must be at least %s", MinSecretKeyRotationInterval)))
+	}
+	return allErrs
+}
+
+// ValidatePlugins -
+func (instance *HorizonSpecCore) ValidatePlugins(basePath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for _, plugin := range slices.Sorted(maps.Keys(instance.Plugins)) {
+		if !slices.Contains(DashboardPlugins, plugin) {
+			allErrs = append(allErrs, field.NotSupported(
+				basePath.Child("plugins").Key(plugin), plugin, DashboardPlugins))
+		}
+	}
+	return allErrs
+}
+
+// ValidatePolicies -
+func (instance *HorizonSpecCore) ValidatePolicies(basePath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for _, service := range slices.Sorted(maps.Keys(instance.Policies)) {
+		if !slices.Contains(PolicyServices, service) {
+			allErrs = append(allErrs, field.NotSupported(
+				basePath.Child("policies").Key(service), service, PolicyServices))
+		}
+	}
+	return allErrs
+}
@@ -0,0 +1,252 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	// MinSecretKeyLength - shortest SECRET_KEY accepted, as checked by the
+	// Django security.W009 check
+	MinSecretKeyLength = 50
+	// MinSecretKeyUniqueCharacters - fewest distinct characters accepted in
+	// SECRET_KEY, as checked by the Django security.W009 check
+	MinSecretKeyUniqueCharacters = 5
+)
+
+// Static errors of the SECRET_KEY validation
+var (
+	ErrSecretKeyTooShort   = errors.New("SECRET_KEY is too short")
+	ErrSecretKeyLowEntropy = errors.New("SECRET_KEY has too few unique characters")
+)
+
+// ValidateSecretKey - checks that the SECRET_KEY supplied through Secret is
+// long and random enough to sign the sessions
+func ValidateSecretKey(key string) error {
+	if n := len([]rune(key)); n < MinSecretKeyLength {
+		return fmt.Errorf("%w: %d characters, at least %d are required",
+			ErrSecretKeyTooShort, n, MinSecretKeyLength)
+	}
+	unique := map[rune]struct{}{}
+	for _, c := range key {
+		unique[c] = struct{}{}
+	}
+	if len(unique) < MinSecretKeyUniqueCharacters {
+		return fmt.Errorf("%w: %d unique characters, at least %d are required",
+			ErrSecretKeyLowEntropy, len(unique), MinSecretKeyUniqueCharacters)
+	}
+	return nil
+}
+
+// reservedConfigFiles - files rendered by the operator in the config-data
+// ConfigMap that can't be replaced through DefaultConfigOverwrite
+var reservedConfigFiles = []string{
+	"01-config.conf",
+	"0100_horizon_settings.py",
+	"9999_custom_settings.py",
+	"_9010_horizon_profile.py",
+	"horizon.json",
+	"httpd.conf",
+	"local_settings.py",
+	"ssl.conf",
+}
+
+// Types of the DefaultConfigOverwrite files, which decide where they are
+// placed in the horizon container
+const (
+	// ConfigOverwritePolicy - <service>_policy.{json,yaml,yml} service policy file
+	ConfigOverwritePolicy = "policy"
+	// ConfigOverwriteSettings - *.py local_settings snippet
+	ConfigOverwriteSettings = "settings"
+	// ConfigOverwriteHttpd - *.conf httpd snippet
+	ConfigOverwriteHttpd = "httpd"
+)
+
+// GetConfigOverwriteType - returns the type of a DefaultConfigOverwrite key
+// according to its name, an empty string when it doesn't match any of them
+func GetConfigOverwriteType(name string) string {
+	switch ext := path.Ext(name); {
+	case strings.HasSuffix(strings.TrimSuffix(name, ext), "_policy") &&
+		slices.Contains([]string{".json", ".yaml", ".yml"}, ext):
+		return ConfigOverwritePolicy
+	case ext == ".py":
+		return ConfigOverwriteSettings
+	case ext == ".conf":
+		return ConfigOverwriteHttpd
+	}
+	return ""
+}
+
+// operatorOwnedSettings matches the statements changing a setting rendered
+// by the operator in local_settings.py
+var operatorOwnedSettings = regexp.MustCompile(
+	`(?m)^[ \t]*(SECRET_KEY|CACHES|OPENSTACK_KEYSTONE_URL|ALLOWED_HOSTS|CSRF_TRUSTED_ORIGINS)\b`)
+
+// ValidateConfig - validates the CustomServiceConfig and DefaultConfigOverwrite
+// parameters, rejecting the content that would break the Deployment
+func (instance *HorizonSpecCore) ValidateConfig(basePath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	customPath := basePath.Child("customServiceConfig")
+	if err := checkPythonSyntax(instance.CustomServiceConfig); err != nil {
+		allErrs = append(allErrs, field.Invalid(customPath, instance.CustomServiceConfig, err.Error()))
+	}
+
+	overwritePath := basePath.Child("defaultConfigOverwrite")
+	for _, name := range slices.Sorted(maps.Keys(instance.DefaultConfigOverwrite)) {
+		content := instance.DefaultConfigOverwrite[name]
+		if slices.Contains(reservedConfigFiles, name) {
+			allErrs = append(allErrs, field.Forbidden(overwritePath.Key(name),
+				fmt.Sprintf("%s is rendered by the operator and can't be overwritten", name)))
+			continue
+		}
+		fileType := GetConfigOverwriteType(name)
+		if fileType == "" {
+			allErrs = append(allErrs, field.Invalid(overwritePath.Key(name), name,
+				"no known destination, the supported names are <service>_policy.{json,yaml,yml}, *.py and *.conf"))
+			continue
+		}
+		if fileType != ConfigOverwriteSettings {
+			continue
+		}
+		if err := checkPythonSyntax(content); err != nil {
+			allErrs = append(allErrs, field.Invalid(overwritePath.Key(name), content, err.Error()))
+		}
+	}
+	return allErrs
+}
+
+// GetConfigWarnings - returns a warning for each setting owned by the
+// operator that is overridden in CustomServiceConfig or in the Python
+// snippets of DefaultConfigOverwrite
+func (instance *HorizonSpecCore) GetConfigWarnings(basePath *field.Path) admission.Warnings {
+	var allWarns admission.Warnings
+
+	allWarns = append(allWarns, checkOperatorOwnedSettings(
+		basePath.Child("customServiceConfig"), instance.CustomServiceConfig)...)
+
+	overwritePath := basePath.Child("defaultConfigOverwrite")
+	for _, name := range slices.Sorted(maps.Keys(instance.DefaultConfigOverwrite)) {
+		if slices.Contains(reservedConfigFiles, name) || GetConfigOverwriteType(name) != ConfigOverwriteSettings {
+			continue
+		}
+		allWarns = append(allWarns, checkOperatorOwnedSettings(
+			overwritePath.Key(name), instance.DefaultConfigOverwrite[name])...)
+	}
+	return allWarns
+}
+
+// checkOperatorOwnedSettings - returns a warning for each setting owned by the
+// operator that is changed by the given Python snippet
+func checkOperatorOwnedSettings(path *field.Path, src string) admission.Warnings {
+	var warns admission.Warnings
+	seen := map[string]bool{}
+	for _, match := range operatorOwnedSettings.FindAllStringSubmatch(src, -1) {
+		name := match[1]
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		warns = append(warns, fmt.Sprintf(
+			"%s: %s is managed by the horizon-operator, overriding it may break the dashboard",
+			path.String(), name))
+	}
+	return warns
+}
+
+// checkPythonSyntax - performs a lightweight syntax check of a Python snippet.
+// It is not a parser: it only detects unterminated strings and unbalanced
+// brackets, which are the most common mistakes that would otherwise show up as
+// a crashlooping pod. The indentation is left to Python, as the snippet can't
+// be parsed without a tokenizer
+func checkPythonSyntax(src string) error {
+	type bracket struct {
+		char byte
+		line int
+	}
+	closing := map[byte]byte{')': '(', ']': '[', '}': '{'}
+
+	// Python reads the snippets with universal newlines
+	src = strings.ReplaceAll(src, "\r\n", "\n")
+	src = strings.ReplaceAll(src, "\r", "\n")
+
+	var stack []bracket
+	// delimiter of the string being scanned, if any
+	quote := ""
+	quoteLine := 0
+
+	for n, line := range strings.Split(src, "\n") {
+		lineNo := n + 1
+	scan:
+		for i := 0; i < len(line); i++ {
+			c := line[i]
+			if quote != "" {
+				if c == '\\' {
+					i++
+					continue
+				}
+				if strings.HasPrefix(line[i:], quote) {
+					i += len(quote) - 1
+					quote = ""
+				}
+				continue
+			}
+			switch c {
+			case '#':
+				break scan
+			case '\'', '"':
+				quote = string(c)
+				if strings.HasPrefix(line[i:], strings.Repeat(quote, 3)) {
+					quote = strings.Repeat(quote, 3)
+				}
+				quoteLine = lineNo
+				i += len(quote) - 1
+			case '(', '[', '{':
+				stack = append(stack, bracket{c, lineNo})
+			case ')', ']', '}':
+				if len(stack) == 0 || stack[len(stack)-1].char != closing[c] {
+					return fmt.Errorf("line %d: unmatched '%c'", lineNo, c)
+				}
+				stack = stack[:len(stack)-1]
+			}
+		}
+
+		// only triple quoted strings can span multiple lines
+		if len(quote) == 1 && !strings.HasSuffix(line, "\\") {
+			return fmt.Errorf("line %d: unterminated string literal", quoteLine)
+		}
+	}
+
+	switch {
+	case quote != "":
+		return fmt.Errorf("line %d: unterminated string literal", quoteLine)
+	case len(stack) != 0:
+		b := stack[len(stack)-1]
+		return fmt.Errorf("line %d: '%c' was never closed", b.line, b.char)
+	}
+	return nil
+}
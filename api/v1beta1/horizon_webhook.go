@@ -23,6 +23,8 @@ limitations under the License.
 package v1beta1
 
 import (
+	"errors"
+
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -38,6 +40,12 @@ type HorizonDefaults struct {
 
 var horizonDefaults HorizonDefaults
 
+// routeAPIServed - whether the cluster serves the OpenShift Route API. When
+// it doesn't the operator exposes spec.ingress through an Ingress, which
+// can't pass TLS through. It is assumed to be served until the operator
+// reports otherwise
+var routeAPIServed = true
+
 // log is for logging in this package.
 var horizonlog = logf.Log.WithName("horizon-resource")
 
@@ -47,6 +55,13 @@ func SetupHorizonDefaults(defaults HorizonDefaults) {
 	horizonlog.Info("Horizon defaults initialized", "defaults", defaults)
 }
 
+// SetupRouteAPIServed - records whether the cluster serves the OpenShift Route
+// API, checked by ValidateIngress
+func SetupRouteAPIServed(served bool) {
+	routeAPIServed = served
+	horizonlog.Info("Route API availability initialized", "served", served)
+}
+
 // Default sets default values for the Horizon resource
 func (r *Horizon) Default() {
 	horizonlog.Info("default", "name", r.Name)
@@ -65,45 +80,91 @@ func (spec *HorizonSpec) Default() {
 
 // Default - set defaults for this Horizon spec core (this one gets used by OpenstackControlPlane)
 func (spec *HorizonSpecCore) Default() {
-	// nothing here yet
+	if spec.SSO != nil {
+		for i, idp := range spec.SSO.IdentityProviders {
+			if idp.DisplayName == "" {
+				spec.SSO.IdentityProviders[i].DisplayName = idp.Name
+			}
+		}
+	}
 }
 
 // ValidateCreate validates the Horizon resource upon creation
 func (r *Horizon) ValidateCreate() (admission.Warnings, error) {
 	horizonlog.Info("validate create", "name", r.Name)
 
-	var allErrs field.ErrorList
-	basePath := field.NewPath("spec")
+	// warn when a setting owned by the operator is overridden
+	allWarns := r.Spec.GetConfigWarnings(field.NewPath("spec"))
 
-	// When a TopologyRef CR is referenced, fail if a different Namespace is
-	// referenced because is not supported
-	allErrs = append(allErrs, r.Spec.ValidateTopology(basePath, r.Namespace)...)
-
-	if len(allErrs) != 0 {
-		return nil, apierrors.NewInvalid(
+	if allErrs := r.Spec.ValidateCreate(field.NewPath("spec"), r.Namespace); len(allErrs) != 0 {
+		return allWarns, apierrors.NewInvalid(
 			schema.GroupKind{Group: "horizon.openstack.org", Kind: "Horizon"},
 			r.Name, allErrs)
 	}
-	return nil, nil
+	return allWarns, nil
+}
+
+// ValidateCreate - Exported function wrapping non-exported validate functions,
+// this function can be called externally to validate a horizon spec.
+func (spec *HorizonSpecCore) ValidateCreate(basePath *field.Path, namespace string) field.ErrorList {
+	return spec.validate(basePath, namespace)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
 func (r *Horizon) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
 	horizonlog.Info("validate update", "name", r.Name)
 
-	var allErrs field.ErrorList
-	basePath := field.NewPath("spec")
+	oldHorizon, ok := old.(*Horizon)
+	if !ok || oldHorizon == nil {
+		return nil, apierrors.NewInternalError(errors.New("unable to convert existing object"))
+	}
 
-	// When a TopologyRef CR is referenced, fail if a different Namespace is
-	// referenced because is not supported
-	allErrs = append(allErrs, r.Spec.ValidateTopology(basePath, r.Namespace)...)
+	// warn when a setting owned by the operator is overridden
+	allWarns := r.Spec.GetConfigWarnings(field.NewPath("spec"))
 
-	if len(allErrs) != 0 {
-		return nil, apierrors.NewInvalid(
+	if allErrs := r.Spec.ValidateUpdate(oldHorizon.Spec.HorizonSpecCore, field.NewPath("spec"), r.Namespace); len(allErrs) != 0 {
+		return allWarns, apierrors.NewInvalid(
 			schema.GroupKind{Group: "horizon.openstack.org", Kind: "Horizon"},
 			r.Name, allErrs)
 	}
-	return nil, nil
+	return allWarns, nil
+}
+
+// ValidateUpdate - Exported function wrapping non-exported validate functions,
+// this function can be called externally to validate a horizon spec.
+func (spec *HorizonSpecCore) ValidateUpdate(old HorizonSpecCore, basePath *field.Path, namespace string) field.ErrorList {
+	// no update specific check yet, the spec is validated as a whole
+	return spec.validate(basePath, namespace)
+}
+
+// validate - checks the fields of the spec which are validated both on
+// creation and on update
+func (spec *HorizonSpecCore) validate(basePath *field.Path, namespace string) field.ErrorList {
+	var allErrs field.ErrorList
+
+	// When a TopologyRef CR is referenced, fail if a different Namespace is
+	// referenced because is not supported
+	allErrs = append(allErrs, spec.ValidateTopology(basePath, namespace)...)
+	allErrs = append(allErrs, spec.ValidateSSO(basePath)...)
+	allErrs = append(allErrs, spec.ValidateSettings(basePath)...)
+	allErrs = append(allErrs, spec.ValidatePolicies(basePath)...)
+	allErrs = append(allErrs, spec.ValidatePlugins(basePath)...)
+	allErrs = append(allErrs, spec.ValidateAutoscaling(basePath)...)
+	allErrs = append(allErrs, spec.ValidatePodDisruptionBudget(basePath)...)
+	allErrs = append(allErrs, spec.ValidateRolloutStrategy(basePath)...)
+	allErrs = append(allErrs, spec.ValidateSessionBackend(basePath)...)
+	allErrs = append(allErrs, spec.ValidateSecretKeyRotation(basePath)...)
+	allErrs = append(allErrs, spec.ValidatePasswordSelectors(basePath)...)
+	allErrs = append(allErrs, spec.ValidateEndpointInterfaces(basePath)...)
+	allErrs = append(allErrs, spec.ValidateRegions(basePath)...)
+	allErrs = append(allErrs, spec.ValidateIngress(basePath)...)
+	allErrs = append(allErrs, spec.ValidateGateway(basePath)...)
+	allErrs = append(allErrs, spec.ValidateWebRoot(basePath)...)
+	allErrs = append(allErrs, spec.ValidateAdditionalHostnames(basePath)...)
+	// Reject broken CustomServiceConfig/DefaultConfigOverwrite content
+	allErrs = append(allErrs, spec.ValidateConfig(basePath)...)
+
+	return allErrs
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
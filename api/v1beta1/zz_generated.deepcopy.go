@@ -25,7 +25,10 @@ import (
 	"github.com/openstack-k8s-operators/lib-common/modules/common/condition"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/service"
 	"github.com/openstack-k8s-operators/lib-common/modules/storage"
+	"k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -36,6 +39,11 @@ func (in *HorizionOverrideSpec) DeepCopyInto(out *HorizionOverrideSpec) {
 		*out = new(service.RoutedOverrideSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Gateway != nil {
+		in, out := &in.Gateway, &out.Gateway
+		*out = new(HorizonGatewaySpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HorizionOverrideSpec.
@@ -75,6 +83,56 @@ func (in *Horizon) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HorizonAutoscalingSpec) DeepCopyInto(out *HorizonAutoscalingSpec) {
+	*out = *in
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TargetCPUUtilizationPercentage != nil {
+		in, out := &in.TargetCPUUtilizationPercentage, &out.TargetCPUUtilizationPercentage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TargetMemoryUtilizationPercentage != nil {
+		in, out := &in.TargetMemoryUtilizationPercentage, &out.TargetMemoryUtilizationPercentage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Behavior != nil {
+		in, out := &in.Behavior, &out.Behavior
+		*out = new(v2.HorizontalPodAutoscalerBehavior)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HorizonAutoscalingSpec.
+func (in *HorizonAutoscalingSpec) DeepCopy() *HorizonAutoscalingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HorizonAutoscalingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HorizonDatabaseSpec) DeepCopyInto(out *HorizonDatabaseSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HorizonDatabaseSpec.
+func (in *HorizonDatabaseSpec) DeepCopy() *HorizonDatabaseSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HorizonDatabaseSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HorizonDefaults) DeepCopyInto(out *HorizonDefaults) {
 	*out = *in
@@ -90,6 +148,21 @@ func (in *HorizonDefaults) DeepCopy() *HorizonDefaults {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HorizonEndpointInterfacesSpec) DeepCopyInto(out *HorizonEndpointInterfacesSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HorizonEndpointInterfacesSpec.
+func (in *HorizonEndpointInterfacesSpec) DeepCopy() *HorizonEndpointInterfacesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HorizonEndpointInterfacesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HorizonExtraVolMounts) DeepCopyInto(out *HorizonExtraVolMounts) {
 	*out = *in
@@ -112,6 +185,91 @@ func (in *HorizonExtraVolMounts) DeepCopy() *HorizonExtraVolMounts {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HorizonGatewayParentRef) DeepCopyInto(out *HorizonGatewayParentRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HorizonGatewayParentRef.
+func (in *HorizonGatewayParentRef) DeepCopy() *HorizonGatewayParentRef {
+	if in == nil {
+		return nil
+	}
+	out := new(HorizonGatewayParentRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HorizonGatewaySpec) DeepCopyInto(out *HorizonGatewaySpec) {
+	*out = *in
+	out.ParentRef = in.ParentRef
+	if in.Hostnames != nil {
+		in, out := &in.Hostnames, &out.Hostnames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HorizonGatewaySpec.
+func (in *HorizonGatewaySpec) DeepCopy() *HorizonGatewaySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HorizonGatewaySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HorizonIngressSpec) DeepCopyInto(out *HorizonIngressSpec) {
+	*out = *in
+	if in.IngressClassName != nil {
+		in, out := &in.IngressClassName, &out.IngressClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HorizonIngressSpec.
+func (in *HorizonIngressSpec) DeepCopy() *HorizonIngressSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HorizonIngressSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HorizonKeystoneAPIRef) DeepCopyInto(out *HorizonKeystoneAPIRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HorizonKeystoneAPIRef.
+func (in *HorizonKeystoneAPIRef) DeepCopy() *HorizonKeystoneAPIRef {
+	if in == nil {
+		return nil
+	}
+	out := new(HorizonKeystoneAPIRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HorizonList) DeepCopyInto(out *HorizonList) {
 	*out = *in
@@ -144,6 +302,216 @@ func (in *HorizonList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HorizonPasswordValidator) DeepCopyInto(out *HorizonPasswordValidator) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HorizonPasswordValidator.
+func (in *HorizonPasswordValidator) DeepCopy() *HorizonPasswordValidator {
+	if in == nil {
+		return nil
+	}
+	out := new(HorizonPasswordValidator)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HorizonPodDisruptionBudgetSpec) DeepCopyInto(out *HorizonPodDisruptionBudgetSpec) {
+	*out = *in
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HorizonPodDisruptionBudgetSpec.
+func (in *HorizonPodDisruptionBudgetSpec) DeepCopy() *HorizonPodDisruptionBudgetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HorizonPodDisruptionBudgetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HorizonPolicySource) DeepCopyInto(out *HorizonPolicySource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HorizonPolicySource.
+func (in *HorizonPolicySource) DeepCopy() *HorizonPolicySource {
+	if in == nil {
+		return nil
+	}
+	out := new(HorizonPolicySource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HorizonRegionSpec) DeepCopyInto(out *HorizonRegionSpec) {
+	*out = *in
+	if in.KeystoneAPI != nil {
+		in, out := &in.KeystoneAPI, &out.KeystoneAPI
+		*out = new(HorizonKeystoneAPIRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HorizonRegionSpec.
+func (in *HorizonRegionSpec) DeepCopy() *HorizonRegionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HorizonRegionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HorizonRolloutStrategy) DeepCopyInto(out *HorizonRolloutStrategy) {
+	*out = *in
+	if in.MaxSurge != nil {
+		in, out := &in.MaxSurge, &out.MaxSurge
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MinReadySeconds != nil {
+		in, out := &in.MinReadySeconds, &out.MinReadySeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ProgressDeadlineSeconds != nil {
+		in, out := &in.ProgressDeadlineSeconds, &out.ProgressDeadlineSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HorizonRolloutStrategy.
+func (in *HorizonRolloutStrategy) DeepCopy() *HorizonRolloutStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(HorizonRolloutStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HorizonSSOIdentityProvider) DeepCopyInto(out *HorizonSSOIdentityProvider) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HorizonSSOIdentityProvider.
+func (in *HorizonSSOIdentityProvider) DeepCopy() *HorizonSSOIdentityProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(HorizonSSOIdentityProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HorizonSSOSpec) DeepCopyInto(out *HorizonSSOSpec) {
+	*out = *in
+	if in.IdentityProviders != nil {
+		in, out := &in.IdentityProviders, &out.IdentityProviders
+		*out = make([]HorizonSSOIdentityProvider, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HorizonSSOSpec.
+func (in *HorizonSSOSpec) DeepCopy() *HorizonSSOSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HorizonSSOSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HorizonSecretKeyRotationSpec) DeepCopyInto(out *HorizonSecretKeyRotationSpec) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HorizonSecretKeyRotationSpec.
+func (in *HorizonSecretKeyRotationSpec) DeepCopy() *HorizonSecretKeyRotationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HorizonSecretKeyRotationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HorizonSessionKeySpec) DeepCopyInto(out *HorizonSessionKeySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HorizonSessionKeySpec.
+func (in *HorizonSessionKeySpec) DeepCopy() *HorizonSessionKeySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HorizonSessionKeySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HorizonSettings) DeepCopyInto(out *HorizonSettings) {
+	*out = *in
+	if in.SessionTimeout != nil {
+		in, out := &in.SessionTimeout, &out.SessionTimeout
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PasswordValidator != nil {
+		in, out := &in.PasswordValidator, &out.PasswordValidator
+		*out = new(HorizonPasswordValidator)
+		**out = **in
+	}
+	if in.KeystoneMultiDomainSupport != nil {
+		in, out := &in.KeystoneMultiDomainSupport, &out.KeystoneMultiDomainSupport
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HorizonSettings.
+func (in *HorizonSettings) DeepCopy() *HorizonSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(HorizonSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HorizonSpec) DeepCopyInto(out *HorizonSpec) {
 	*out = *in
@@ -189,6 +557,11 @@ func (in *HorizonSpecCore) DeepCopyInto(out *HorizonSpecCore) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.Database != nil {
+		in, out := &in.Database, &out.Database
+		*out = new(HorizonDatabaseSpec)
+		**out = **in
+	}
 	if in.ExtraMounts != nil {
 		in, out := &in.ExtraMounts, &out.ExtraMounts
 		*out = make([]HorizonExtraVolMounts, len(*in))
@@ -206,6 +579,77 @@ func (in *HorizonSpecCore) DeepCopyInto(out *HorizonSpecCore) {
 		*out = new(topologyv1beta1.TopoRef)
 		**out = **in
 	}
+	if in.SSO != nil {
+		in, out := &in.SSO, &out.SSO
+		*out = new(HorizonSSOSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Settings != nil {
+		in, out := &in.Settings, &out.Settings
+		*out = new(HorizonSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make(map[string]HorizonPolicySource, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Plugins != nil {
+		in, out := &in.Plugins, &out.Plugins
+		*out = make(map[string]HorizonPluginMode, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(HorizonAutoscalingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(HorizonPodDisruptionBudgetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RolloutStrategy != nil {
+		in, out := &in.RolloutStrategy, &out.RolloutStrategy
+		*out = new(HorizonRolloutStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SessionKey != nil {
+		in, out := &in.SessionKey, &out.SessionKey
+		*out = new(HorizonSessionKeySpec)
+		**out = **in
+	}
+	if in.SecretKeyRotation != nil {
+		in, out := &in.SecretKeyRotation, &out.SecretKeyRotation
+		*out = new(HorizonSecretKeyRotationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EndpointInterfaces != nil {
+		in, out := &in.EndpointInterfaces, &out.EndpointInterfaces
+		*out = new(HorizonEndpointInterfacesSpec)
+		**out = **in
+	}
+	if in.Regions != nil {
+		in, out := &in.Regions, &out.Regions
+		*out = make([]HorizonRegionSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(HorizonIngressSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdditionalHostnames != nil {
+		in, out := &in.AdditionalHostnames, &out.AdditionalHostnames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HorizonSpecCore.
@@ -256,6 +700,20 @@ func (in *HorizonStatus) DeepCopyInto(out *HorizonStatus) {
 		*out = new(topologyv1beta1.TopoRef)
 		**out = **in
 	}
+	if in.EnabledPlugins != nil {
+		in, out := &in.EnabledPlugins, &out.EnabledPlugins
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SecretKeyLastRotated != nil {
+		in, out := &in.SecretKeyLastRotated, &out.SecretKeyLastRotated
+		*out = (*in).DeepCopy()
+	}
+	if in.Hostnames != nil {
+		in, out := &in.Hostnames, &out.Hostnames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HorizonStatus.
@@ -267,3 +725,18 @@ func (in *HorizonStatus) DeepCopy() *HorizonStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PasswordSelector) DeepCopyInto(out *PasswordSelector) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordSelector.
+func (in *PasswordSelector) DeepCopy() *PasswordSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(PasswordSelector)
+	in.DeepCopyInto(out)
+	return out
+}
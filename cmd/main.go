@@ -27,6 +27,7 @@ import (
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
@@ -40,6 +41,7 @@ import (
 
 	horizonv1beta1 "github.com/openstack-k8s-operators/horizon-operator/api/v1beta1"
 	"github.com/openstack-k8s-operators/horizon-operator/internal/controller"
+	"github.com/openstack-k8s-operators/horizon-operator/internal/horizon"
 	webhookv1beta1 "github.com/openstack-k8s-operators/horizon-operator/internal/webhook/v1beta1"
 
 	// +kubebuilder:scaffold:imports
@@ -47,9 +49,11 @@ import (
 
 	networkv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 	memcachedv1 "github.com/openstack-k8s-operators/infra-operator/apis/memcached/v1beta1"
+	redisv1 "github.com/openstack-k8s-operators/infra-operator/apis/redis/v1beta1"
 	topologyv1 "github.com/openstack-k8s-operators/infra-operator/apis/topology/v1beta1"
 	keystonev1 "github.com/openstack-k8s-operators/keystone-operator/api/v1beta1"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/operator"
+	mariadbv1 "github.com/openstack-k8s-operators/mariadb-operator/api/v1beta1"
 	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 )
@@ -65,7 +69,9 @@ func init() {
 	utilruntime.Must(networkv1.AddToScheme(scheme))
 	utilruntime.Must(horizonv1beta1.AddToScheme(scheme))
 	utilruntime.Must(keystonev1.AddToScheme(scheme))
+	utilruntime.Must(mariadbv1.AddToScheme(scheme))
 	utilruntime.Must(memcachedv1.AddToScheme(scheme))
+	utilruntime.Must(redisv1.AddToScheme(scheme))
 	utilruntime.Must(topologyv1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
@@ -249,9 +255,10 @@ func main() {
 	}
 
 	if err := (&controller.HorizonReconciler{
-		Client:  mgr.GetClient(),
-		Scheme:  mgr.GetScheme(),
-		Kclient: kclient,
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Kclient:  kclient,
+		Recorder: mgr.GetEventRecorderFor("horizon-controller"),
 	}).SetupWithManager(context.Background(), mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Horizon")
 		os.Exit(1)
@@ -260,6 +267,15 @@ func main() {
 	// Acquire environmental defaults and initialize operator defaults with them
 	horizonv1beta1.SetupDefaults()
 
+	// spec.ingress is exposed through an Ingress, which can't pass TLS
+	// through, on the clusters not serving the OpenShift Route API
+	_, err = mgr.GetRESTMapper().RESTMapping(horizon.RouteGVK.GroupKind(), horizon.RouteGVK.Version)
+	if err != nil && !meta.IsNoMatchError(err) {
+		setupLog.Error(err, "unable to check the Route API")
+		os.Exit(1)
+	}
+	horizonv1beta1.SetupRouteAPIServed(err == nil)
+
 	// nolint:goconst
 	checker := healthz.Ping
 	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
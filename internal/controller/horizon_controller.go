@@ -24,6 +24,7 @@ import (
 	"maps"
 	"net/url"
 	"slices"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -31,6 +32,7 @@ import (
 	horizonv1beta1 "github.com/openstack-k8s-operators/horizon-operator/api/v1beta1"
 	horizon "github.com/openstack-k8s-operators/horizon-operator/internal/horizon"
 	memcachedv1 "github.com/openstack-k8s-operators/infra-operator/apis/memcached/v1beta1"
+	redisv1 "github.com/openstack-k8s-operators/infra-operator/apis/redis/v1beta1"
 	topologyv1 "github.com/openstack-k8s-operators/infra-operator/apis/topology/v1beta1"
 	keystonev1 "github.com/openstack-k8s-operators/keystone-operator/api/v1beta1"
 	"github.com/openstack-k8s-operators/lib-common/modules/common"
@@ -40,6 +42,7 @@ import (
 	endpoint "github.com/openstack-k8s-operators/lib-common/modules/common/endpoint"
 	env "github.com/openstack-k8s-operators/lib-common/modules/common/env"
 	helper "github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+	job "github.com/openstack-k8s-operators/lib-common/modules/common/job"
 	labels "github.com/openstack-k8s-operators/lib-common/modules/common/labels"
 	nad "github.com/openstack-k8s-operators/lib-common/modules/common/networkattachment"
 	common_rbac "github.com/openstack-k8s-operators/lib-common/modules/common/rbac"
@@ -47,15 +50,25 @@ import (
 	"github.com/openstack-k8s-operators/lib-common/modules/common/service"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/tls"
 	util "github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	mariadbv1 "github.com/openstack-k8s-operators/mariadb-operator/api/v1beta1"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -71,6 +84,10 @@ import (
 var (
 	ErrNoOpenstackSecret       = errors.New("no openstack secret has been provided")
 	ErrNetworkAttachmentConfig = errors.New("not all pods have interfaces with ips as configured in NetworkAttachments")
+	ErrPolicyConfigMap         = errors.New("invalid policy configmap")
+	ErrGatewayAPINotServed     = errors.New("the Gateway API is not served by the cluster")
+	ErrIngressPassthrough      = errors.New("passthrough termination requires an OpenShift Route")
+	ErrHTTPRouteBackend        = errors.New("unable to resolve the backend of the HTTPRoute")
 )
 
 // GetClient -
@@ -96,8 +113,9 @@ func (r *HorizonReconciler) GetLogger(ctx context.Context) logr.Logger {
 // HorizonReconciler reconciles a Horizon object
 type HorizonReconciler struct {
 	client.Client
-	Kclient kubernetes.Interface
-	Scheme  *runtime.Scheme
+	Kclient  kubernetes.Interface
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
 }
 
 //+kubebuilder:rbac:groups=horizon.openstack.org,resources=horizons,verbs=get;list;watch;create;update;patch;delete
@@ -105,12 +123,26 @@ type HorizonReconciler struct {
 //+kubebuilder:rbac:groups=horizon.openstack.org,resources=horizons/finalizers,verbs=update;patch
 //+kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch;delete;
 //+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete;
+//+kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete;
+//+kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete;
+//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;update;patch;delete;
 //+kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch;delete;
 //+kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete;
+//+kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
 //+kubebuilder:rbac:groups=keystone.openstack.org,resources=keystoneapis,verbs=get;list;watch;
 //+kubebuilder:rbac:groups=keystone.openstack.org,resources=keystoneendpoints,verbs=get;list;watch;
 //+kubebuilder:rbac:groups=keystone.openstack.org,resources=keystoneservices,verbs=get;list;watch;
 //+kubebuilder:rbac:groups=memcached.openstack.org,resources=memcacheds,verbs=get;list;watch;
+//+kubebuilder:rbac:groups=redis.openstack.org,resources=redises,verbs=get;list;watch;
+//+kubebuilder:rbac:groups=mariadb.openstack.org,resources=galeras,verbs=get;list;watch;
+//+kubebuilder:rbac:groups=mariadb.openstack.org,resources=mariadbdatabases,verbs=get;list;watch;create;update;patch;delete;
+//+kubebuilder:rbac:groups=mariadb.openstack.org,resources=mariadbaccounts,verbs=get;list;watch;create;update;patch;delete;
+//+kubebuilder:rbac:groups=mariadb.openstack.org,resources=mariadbaccounts/finalizers,verbs=update;patch
+//+kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete;
+//+kubebuilder:rbac:groups=route.openshift.io,resources=routes,verbs=get;list;watch;create;update;patch;delete;
+//+kubebuilder:rbac:groups=route.openshift.io,resources=routes/custom-host,verbs=create;update;patch
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;patch;delete;
+//+kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gateways,verbs=get;list;watch;
 
 // service account, role, rolebinding
 // +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;update;patch
@@ -190,10 +222,11 @@ func (r *HorizonReconciler) Reconcile(ctx context.Context, req ctrl.Request) (re
 
 	cl := condition.CreateList(
 		condition.UnknownCondition(condition.InputReadyCondition, condition.InitReason, condition.InputReadyInitMessage),
-		condition.UnknownCondition(condition.MemcachedReadyCondition, condition.InitReason, condition.MemcachedReadyInitMessage),
 		condition.UnknownCondition(condition.ServiceConfigReadyCondition, condition.InitReason, condition.ServiceConfigReadyInitMessage),
 		condition.UnknownCondition(condition.CreateServiceReadyCondition, condition.InitReason, condition.CreateServiceReadyInitMessage),
 		condition.UnknownCondition(condition.DeploymentReadyCondition, condition.InitReason, condition.DeploymentReadyInitMessage),
+		condition.UnknownCondition(horizonv1beta1.HorizonConfigOverwriteReadyCondition, condition.InitReason, horizonv1beta1.HorizonConfigOverwriteReadyInitMessage),
+		condition.UnknownCondition(horizonv1beta1.HorizonPodDisruptionBudgetReadyCondition, condition.InitReason, horizonv1beta1.HorizonPodDisruptionBudgetReadyInitMessage),
 		// service account, role, rolebinding conditions
 		condition.UnknownCondition(condition.ServiceAccountReadyCondition, condition.InitReason, condition.ServiceAccountReadyInitMessage),
 		condition.UnknownCondition(condition.RoleReadyCondition, condition.InitReason, condition.RoleReadyInitMessage),
@@ -201,6 +234,29 @@ func (r *HorizonReconciler) Reconcile(ctx context.Context, req ctrl.Request) (re
 		condition.UnknownCondition(condition.TLSInputReadyCondition, condition.InitReason, condition.InputReadyInitMessage),
 	)
 
+	// Init the condition of the session backend, signed cookies have none
+	switch instance.Spec.GetSessionBackend() {
+	case horizonv1beta1.SessionBackendMemcached:
+		cl.Set(condition.UnknownCondition(condition.MemcachedReadyCondition, condition.InitReason, condition.MemcachedReadyInitMessage))
+	case horizonv1beta1.SessionBackendRedis:
+		cl.Set(condition.UnknownCondition(horizonv1beta1.HorizonRedisReadyCondition, condition.InitReason, horizonv1beta1.HorizonRedisReadyInitMessage))
+	case horizonv1beta1.SessionBackendDatabase:
+		cl.Set(condition.UnknownCondition(mariadbv1.MariaDBAccountReadyCondition, condition.InitReason, mariadbv1.MariaDBAccountReadyInitMessage))
+		cl.Set(condition.UnknownCondition(condition.DBReadyCondition, condition.InitReason, condition.DBReadyInitMessage))
+		cl.Set(condition.UnknownCondition(condition.DBSyncReadyCondition, condition.InitReason, condition.DBSyncReadyInitMessage))
+	}
+	// Init the Ingress condition when the operator exposes the dashboard
+	if instance.Spec.Ingress != nil {
+		cl.Set(condition.UnknownCondition(horizonv1beta1.HorizonIngressReadyCondition, condition.InitReason, horizonv1beta1.HorizonIngressReadyInitMessage))
+	}
+	// Init the HTTPRoute condition when the dashboard is exposed through a Gateway
+	if instance.Spec.Override.Gateway != nil {
+		cl.Set(condition.UnknownCondition(horizonv1beta1.HorizonHTTPRouteReadyCondition, condition.InitReason, horizonv1beta1.HorizonHTTPRouteReadyInitMessage))
+	}
+	// Init the Autoscaling condition if an HorizontalPodAutoscaler is requested
+	if instance.Spec.Autoscaling != nil {
+		cl.Set(condition.UnknownCondition(horizonv1beta1.HorizonAutoscalingReadyCondition, condition.InitReason, horizonv1beta1.HorizonAutoscalingReadyInitMessage))
+	}
 	instance.Status.Conditions.Init(&cl)
 	instance.Status.ObservedGeneration = instance.Generation
 
@@ -234,6 +290,11 @@ const (
 	tlsField                = ".spec.tls.secretName"
 	caBundleSecretNameField = ".spec.tls.caBundleSecretName" // #nosec G101
 	topologyField           = ".spec.topologyRef.Name"
+	ssoCredentialsField     = ".spec.sso.identityProviders.credentialsSecret" // #nosec G101
+	policiesField           = ".spec.policies.configMapName"
+	regionCaBundleField     = ".spec.regions.caBundleSecretName" // #nosec G101
+	regionKeystoneAPIField  = ".spec.regions.keystoneAPI"
+	ingressTLSField         = ".spec.ingress.tlsSecretName"
 )
 
 var allWatchFields = []string{
@@ -241,12 +302,15 @@ var allWatchFields = []string{
 	caBundleSecretNameField,
 	tlsField,
 	topologyField,
+	ssoCredentialsField,
+	policiesField,
+	regionCaBundleField,
+	ingressTLSField,
 }
 
-var keystoneServicesWatch = []string{
-	"cloudkitty",
-	"watcher",
-}
+// keystoneServicesWatch - the KeystoneServices enabling the related dashboard
+// plugins
+var keystoneServicesWatch = horizonv1beta1.DashboardPlugins
 
 // SetupWithManager -
 func (r *HorizonReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager) error {
@@ -300,6 +364,74 @@ func (r *HorizonReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manag
 		return err
 	}
 
+	// index ssoCredentialsField
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &horizonv1beta1.Horizon{}, ssoCredentialsField, func(rawObj client.Object) []string {
+		// Extract the SSO credentials secret names from the spec, if any is provided
+		cr := rawObj.(*horizonv1beta1.Horizon)
+		if cr.Spec.SSO == nil {
+			return nil
+		}
+		secrets := []string{}
+		for _, idp := range cr.Spec.SSO.IdentityProviders {
+			if idp.CredentialsSecret != "" {
+				secrets = append(secrets, idp.CredentialsSecret)
+			}
+		}
+		return secrets
+	}); err != nil {
+		return err
+	}
+
+	// index policiesField
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &horizonv1beta1.Horizon{}, policiesField, func(rawObj client.Object) []string {
+		// Extract the policy ConfigMap names from the spec, if any is provided
+		cr := rawObj.(*horizonv1beta1.Horizon)
+		configMaps := []string{}
+		for _, p := range cr.Spec.Policies {
+			configMaps = append(configMaps, p.ConfigMapName)
+		}
+		return configMaps
+	}); err != nil {
+		return err
+	}
+
+	// index regionCaBundleField
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &horizonv1beta1.Horizon{}, regionCaBundleField, func(rawObj client.Object) []string {
+		// Extract the CA bundle secret names of the regions, if any is provided
+		cr := rawObj.(*horizonv1beta1.Horizon)
+		return horizon.GetRegionCABundleSecretNames(cr.Spec.Regions)
+	}); err != nil {
+		return err
+	}
+
+	// index regionKeystoneAPIField
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &horizonv1beta1.Horizon{}, regionKeystoneAPIField, func(rawObj client.Object) []string {
+		// Extract the <namespace>/<name> of the KeystoneAPIs of the regions,
+		// if any is referenced
+		cr := rawObj.(*horizonv1beta1.Horizon)
+		keystoneAPIs := []string{}
+		for _, region := range cr.Spec.Regions {
+			if region.KeystoneAPI != nil {
+				keystoneAPIs = append(keystoneAPIs, getRegionKeystoneAPIName(cr, region.KeystoneAPI).String())
+			}
+		}
+		return keystoneAPIs
+	}); err != nil {
+		return err
+	}
+
+	// index ingressTLSField
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &horizonv1beta1.Horizon{}, ingressTLSField, func(rawObj client.Object) []string {
+		// Extract the secret name of the Route or Ingress, if one is provided
+		cr := rawObj.(*horizonv1beta1.Horizon)
+		if cr.Spec.Ingress == nil || cr.Spec.Ingress.TLSSecretName == "" {
+			return nil
+		}
+		return []string{cr.Spec.Ingress.TLSSecretName}
+	}); err != nil {
+		return err
+	}
+
 	memcachedFn := func(_ context.Context, o client.Object) []reconcile.Request {
 		result := []reconcile.Request{}
 
@@ -314,7 +446,8 @@ func (r *HorizonReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manag
 		}
 
 		for _, cr := range horizons.Items {
-			if o.GetName() == cr.Spec.MemcachedInstance {
+			if cr.Spec.GetSessionBackend() == horizonv1beta1.SessionBackendMemcached &&
+				o.GetName() == cr.Spec.MemcachedInstance {
 				name := client.ObjectKey{
 					Namespace: o.GetNamespace(),
 					Name:      cr.Name,
@@ -329,6 +462,36 @@ func (r *HorizonReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manag
 		return nil
 	}
 
+	redisFn := func(_ context.Context, o client.Object) []reconcile.Request {
+		result := []reconcile.Request{}
+
+		// get all Horizon CRs
+		horizons := &horizonv1beta1.HorizonList{}
+		listOpts := []client.ListOption{
+			client.InNamespace(o.GetNamespace()),
+		}
+		if err := r.List(context.Background(), horizons, listOpts...); err != nil {
+			Log.Error(err, "Unable to retrieve Horizon CRs %w")
+			return nil
+		}
+
+		for _, cr := range horizons.Items {
+			if cr.Spec.GetSessionBackend() == horizonv1beta1.SessionBackendRedis &&
+				o.GetName() == cr.Spec.RedisInstance {
+				name := client.ObjectKey{
+					Namespace: o.GetNamespace(),
+					Name:      cr.Name,
+				}
+				Log.Info(fmt.Sprintf("Redis %s is used by Horizon CR %s", o.GetName(), cr.Name))
+				result = append(result, reconcile.Request{NamespacedName: name})
+			}
+		}
+		if len(result) > 0 {
+			return result
+		}
+		return nil
+	}
+
 	// Watch for changes to NADs
 	nadFn := func(_ context.Context, o client.Object) []reconcile.Request {
 		result := []reconcile.Request{}
@@ -388,17 +551,25 @@ func (r *HorizonReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manag
 		return nil
 	}
 
-	return ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&horizonv1beta1.Horizon{}).
 		Owns(&corev1.Service{}).
+		Owns(&networkingv1.Ingress{}).
 		Owns(&corev1.Secret{}).
 		Owns(&corev1.ConfigMap{}).
 		Owns(&appsv1.Deployment{}).
+		Owns(&autoscalingv2.HorizontalPodAutoscaler{}).
+		Owns(&policyv1.PodDisruptionBudget{}).
+		Owns(&batchv1.Job{}).
+		Owns(&mariadbv1.MariaDBDatabase{}).
+		Owns(&mariadbv1.MariaDBAccount{}).
 		Owns(&corev1.ServiceAccount{}).
 		Owns(&rbacv1.Role{}).
 		Owns(&rbacv1.RoleBinding{}).
 		Watches(&memcachedv1.Memcached{},
 			handler.EnqueueRequestsFromMapFunc(memcachedFn)).
+		Watches(&redisv1.Redis{},
+			handler.EnqueueRequestsFromMapFunc(redisFn)).
 		Watches(&networkv1.NetworkAttachmentDefinition{},
 			handler.EnqueueRequestsFromMapFunc(nadFn)).
 		Watches(
@@ -406,15 +577,33 @@ func (r *HorizonReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manag
 			handler.EnqueueRequestsFromMapFunc(r.findObjectsForSrc),
 			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
 		).
+		Watches(
+			&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.findObjectsForSrc),
+			builder.WithPredicates(predicate.ResourceVersionChangedPredicate{}),
+		).
 		Watches(&keystonev1.KeystoneService{},
 			handler.EnqueueRequestsFromMapFunc(keystoneServiceFn)).
 		Watches(&topologyv1.Topology{},
 			handler.EnqueueRequestsFromMapFunc(r.findObjectsForSrc),
 			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		Watches(&keystonev1.KeystoneAPI{},
-			handler.EnqueueRequestsFromMapFunc(r.findObjectForSrc),
-			builder.WithPredicates(keystonev1.KeystoneAPIStatusChangedPredicate)).
-		Complete(r)
+			handler.EnqueueRequestsFromMapFunc(r.findObjectsForKeystoneAPI),
+			builder.WithPredicates(keystonev1.KeystoneAPIStatusChangedPredicate))
+
+	// the Routes are only watched on OpenShift, the HTTPRoutes where the
+	// Gateway API is installed
+	for _, gvk := range []schema.GroupVersionKind{horizon.RouteGVK, horizon.HTTPRouteGVK} {
+		if _, err := mgr.GetRESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version); err == nil {
+			route := &unstructured.Unstructured{}
+			route.SetGroupVersionKind(gvk)
+			b = b.Owns(route)
+		} else if !meta.IsNoMatchError(err) {
+			return err
+		}
+	}
+
+	return b.Complete(r)
 }
 
 func (r *HorizonReconciler) findObjectsForSrc(ctx context.Context, src client.Object) []reconcile.Request {
@@ -482,10 +671,58 @@ func (r *HorizonReconciler) findObjectForSrc(ctx context.Context, src client.Obj
 	return requests
 }
 
+// findObjectsForKeystoneAPI - returns the Horizon CRs of the namespace of the
+// KeystoneAPI and the ones referencing it in their regions
+func (r *HorizonReconciler) findObjectsForKeystoneAPI(ctx context.Context, src client.Object) []reconcile.Request {
+	requests := r.findObjectForSrc(ctx, src)
+
+	Log := r.GetLogger(ctx)
+
+	crList := &horizonv1beta1.HorizonList{}
+	listOps := &client.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector(regionKeystoneAPIField, client.ObjectKeyFromObject(src).String()),
+	}
+	err := r.List(ctx, crList, listOps)
+	if err != nil {
+		Log.Error(err, fmt.Sprintf("listing %s for field: %s", crList.GroupVersionKind().Kind, regionKeystoneAPIField))
+		return requests
+	}
+
+	for _, item := range crList.Items {
+		request := reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      item.GetName(),
+				Namespace: item.GetNamespace(),
+			},
+		}
+		if slices.Contains(requests, request) {
+			continue
+		}
+		Log.Info(fmt.Sprintf("region KeystoneAPI %s changed, reconcile: %s - %s", src.GetName(), item.GetName(), item.GetNamespace()))
+		requests = append(requests, request)
+	}
+
+	return requests
+}
+
 func (r *HorizonReconciler) reconcileDelete(ctx context.Context, instance *horizonv1beta1.Horizon, helper *helper.Helper) (ctrl.Result, error) {
 	Log := r.GetLogger(ctx)
 	Log.Info("Reconciling Service delete")
 
+	// Remove the finalizer of the MariaDBDatabase storing the sessions
+	if instance.Spec.Database != nil {
+		db, err := mariadbv1.GetDatabaseByNameAndAccount(
+			ctx, helper, horizon.GetDatabaseCRName(instance), instance.Spec.Database.DatabaseAccount, instance.Namespace)
+		if err != nil && !k8s_errors.IsNotFound(err) {
+			return ctrl.Result{}, err
+		}
+		if !k8s_errors.IsNotFound(err) {
+			if err := db.DeleteFinalizer(ctx, helper); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+	}
+
 	// Remove finalizer on the Topology CR
 	if ctrlResult, err := topologyv1.EnsureDeletedTopologyRef(
 		ctx,
@@ -515,7 +752,7 @@ func (r *HorizonReconciler) reconcileInit(
 	//
 	// expose the service (create service and return the created endpoint URL)
 	//
-	endpointName := horizon.ServiceName
+	endpointName := instance.Name
 
 	svcOverride := instance.Spec.Override.Service
 	if svcOverride == nil {
@@ -526,7 +763,7 @@ func (r *HorizonReconciler) reconcileInit(
 	}
 
 	servicePort := corev1.ServicePort{
-		Name:       endpointName,
+		Name:       horizon.ServiceName,
 		Port:       horizon.HorizonSvcPort,
 		TargetPort: intstr.FromInt32(horizon.HorizonPort),
 		Protocol:   corev1.ProtocolTCP,
@@ -560,11 +797,20 @@ func (r *HorizonReconciler) reconcileInit(
 	}
 
 	svc.AddAnnotation(map[string]string{
-		service.AnnotationEndpointKey: string(service.EndpointPublic),
+		service.AnnotationEndpointKey: string(horizon.GetServiceEndpoint(instance.Spec.GetProfile())),
 	})
-
-	// add Annotation to whether creating an ingress is required or not
-	if svc.GetServiceType() == corev1.ServiceTypeClusterIP {
+	svc.AddAnnotation(horizon.GetAdditionalHostnamesAnnotation(instance))
+
+	// add Annotation to whether creating an ingress is required or not, the
+	// admin dashboard is only reachable through the internal Service, and
+	// the operator owns the Route, Ingress or HTTPRoute when spec.ingress or
+	// spec.override.gateway is set
+	if instance.Spec.GetProfile() == horizonv1beta1.ProfileAdmin ||
+		instance.Spec.Ingress != nil || instance.Spec.Override.Gateway != nil {
+		svc.AddAnnotation(map[string]string{
+			service.AnnotationIngressCreateKey: "false",
+		})
+	} else if svc.GetServiceType() == corev1.ServiceTypeClusterIP {
 		svc.AddAnnotation(map[string]string{
 			service.AnnotationIngressCreateKey: "true",
 		})
@@ -611,6 +857,58 @@ func (r *HorizonReconciler) reconcileInit(
 	}
 	instance.Status.Conditions.MarkTrue(condition.CreateServiceReadyCondition, condition.CreateServiceReadyMessage)
 
+	// expose the service through the Route or Ingress owned by the operator
+	ingressEndpoint, err := r.reconcileIngress(ctx, instance, helper, serviceLabels)
+	if errors.Is(err, ErrIngressPassthrough) {
+		// the spec has to change, the Horizon watch reconciles it then
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			horizonv1beta1.HorizonIngressReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityError,
+			horizonv1beta1.HorizonIngressReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, nil
+	}
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			horizonv1beta1.HorizonIngressReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			horizonv1beta1.HorizonIngressReadyErrorMessage,
+			err.Error()))
+		if k8s_errors.IsNotFound(err) {
+			// the Secret holding the certificate of the Route is missing
+			return ctrl.Result{RequeueAfter: time.Second * 10}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	if instance.Spec.Ingress != nil {
+		if ingressEndpoint == "" {
+			// the Route or Ingress has no host name yet
+			return ctrl.Result{RequeueAfter: time.Second * 10}, nil
+		}
+		apiEndpoint = ingressEndpoint
+	}
+
+	// expose the service through the HTTPRoute attached to a Gateway
+	gatewayEndpoint, err := r.reconcileHTTPRoute(ctx, instance, helper, serviceLabels)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			horizonv1beta1.HorizonHTTPRouteReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			horizonv1beta1.HorizonHTTPRouteReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+	if instance.Spec.Override.Gateway != nil {
+		if gatewayEndpoint == "" {
+			// the HTTPRoute is not accepted yet or the Gateway has no address
+			return ctrl.Result{RequeueAfter: time.Second * 10}, nil
+		}
+		apiEndpoint = gatewayEndpoint
+	}
+
 	//
 	// Update instance status with service endpoint url information
 	//
@@ -700,50 +998,79 @@ func (r *HorizonReconciler) reconcileNormal(ctx context.Context, instance *horiz
 	}
 	configMapVars[ospSecret.Name] = env.SetValue(hash)
 
+	// SECRET_KEY supplied by the user, the operator generates one when the
+	// Secret doesn't hold it
+	userSecretKey := string(ospSecret.Data[instance.Spec.PasswordSelectors.SecretKey])
+	if userSecretKey != "" {
+		if err := horizonv1beta1.ValidateSecretKey(userSecretKey); err != nil {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				condition.InputReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				condition.InputReadyErrorMessage,
+				fmt.Sprintf("%s %s: %s", ospSecret.Name, instance.Spec.PasswordSelectors.SecretKey, err)))
+			// the Secret is watched, no need to requeue
+			return ctrl.Result{}, nil
+		}
+	}
+
+	//
+	// check for the Secrets holding the SSO client credentials and add their hash to the vars map
+	//
+	ssoResult, err := r.verifySSOSecrets(ctx, instance, helper, &configMapVars)
+	if err != nil || (ssoResult != ctrl.Result{}) {
+		return ssoResult, err
+	}
+
+	//
+	// check for the ConfigMaps holding the service policy files and add their hash to the vars map
+	//
+	policyResult, err := r.verifyPolicyConfigMaps(ctx, instance, helper, &configMapVars)
+	if err != nil || (policyResult != ctrl.Result{}) {
+		return policyResult, err
+	}
+
 	instance.Status.Conditions.MarkTrue(condition.InputReadyCondition, condition.InputReadyMessage)
 	// run check OpenStack secret - end
 
 	//
-	// Check for required memcached used for caching
+	// Check for the session backend used for caching
 	//
-	memcached, err := memcachedv1.GetMemcachedByName(ctx, helper, instance.Spec.MemcachedInstance, instance.Namespace)
-	if err != nil {
-		if k8s_errors.IsNotFound(err) {
-			// Memcached should be automatically created by the encompassing OpenStackControlPlane,
-			// but we don't propagate its name into the "memcachedInstance" field of other sub-resources,
-			// so if it is missing at this point, it *could* be because there's a mismatch between the
-			// name of the Memcached CR and the name of the Memcached instance referenced by this CR.
-			// Since that situation would block further reconciliation, we treat it as a warning.
-			Log.Info(fmt.Sprintf("memcached %s not found", instance.Spec.MemcachedInstance))
-			instance.Status.Conditions.Set(condition.FalseCondition(
-				condition.MemcachedReadyCondition,
-				condition.ErrorReason,
-				condition.SeverityWarning,
-				condition.MemcachedReadyWaitingMessage))
-			return ctrl.Result{RequeueAfter: time.Second * 10}, nil
+	var memcached *memcachedv1.Memcached
+	var redis *horizon.RedisEndpoint
+	var dbAccount *mariadbv1.MariaDBAccount
+	var backendResult ctrl.Result
+	backend := instance.Spec.GetSessionBackend()
+	if backend != horizonv1beta1.SessionBackendMemcached {
+		instance.Status.Conditions.Remove(condition.MemcachedReadyCondition)
+	}
+	if backend != horizonv1beta1.SessionBackendRedis {
+		instance.Status.Conditions.Remove(horizonv1beta1.HorizonRedisReadyCondition)
+	}
+	if backend != horizonv1beta1.SessionBackendDatabase {
+		instance.Status.Conditions.Remove(mariadbv1.MariaDBAccountReadyCondition)
+		instance.Status.Conditions.Remove(condition.DBReadyCondition)
+		instance.Status.Conditions.Remove(condition.DBSyncReadyCondition)
+		instance.Status.DatabaseHostname = ""
+	}
+	switch backend {
+	case horizonv1beta1.SessionBackendMemcached:
+		memcached, backendResult, err = r.getMemcached(ctx, instance, helper)
+	case horizonv1beta1.SessionBackendRedis:
+		redis, backendResult, err = r.getRedis(ctx, instance, helper)
+		if err == nil && redis == nil && (backendResult == ctrl.Result{}) {
+			// the Redis instance can't be used as is, the reason is
+			// reported in HorizonRedisReady
+			return ctrl.Result{}, nil
 		}
-		instance.Status.Conditions.Set(condition.FalseCondition(
-			condition.MemcachedReadyCondition,
-			condition.ErrorReason,
-			condition.SeverityWarning,
-			condition.MemcachedReadyErrorMessage,
-			err.Error()))
-		return ctrl.Result{}, err
+	case horizonv1beta1.SessionBackendDatabase:
+		dbAccount, backendResult, err = r.ensureDB(ctx, instance, helper, &configMapVars)
 	}
-
-	if !memcached.IsReady() {
-		Log.Info(fmt.Sprintf("memcached %s is not ready", memcached.Name))
-		instance.Status.Conditions.Set(condition.FalseCondition(
-			condition.MemcachedReadyCondition,
-			condition.RequestedReason,
-			condition.SeverityInfo,
-			condition.MemcachedReadyWaitingMessage))
-		return ctrl.Result{RequeueAfter: time.Second * 10}, nil
+	// signed cookies have no server side storage
+	if err != nil || (backendResult != ctrl.Result{}) {
+		return backendResult, err
 	}
-	// Mark the Memcached Service as Ready if we get to this point with no errors
-	instance.Status.Conditions.MarkTrue(
-		condition.MemcachedReadyCondition, condition.MemcachedReadyMessage)
-	// run check memcached - end
+	// run check session backend - end
 
 	//
 	// TLS input validation
@@ -783,6 +1110,39 @@ func (r *HorizonReconciler) reconcileNormal(ctx context.Context, instance *horiz
 		}
 	}
 
+	// Validate the CA bundles of the regions
+	for _, secretName := range horizon.GetRegionCABundleSecretNames(instance.Spec.Regions) {
+		hash, err := tls.ValidateCACertSecret(
+			ctx,
+			helper.GetClient(),
+			types.NamespacedName{
+				Name:      secretName,
+				Namespace: instance.Namespace,
+			},
+		)
+		if err != nil {
+			if k8s_errors.IsNotFound(err) {
+				instance.Status.Conditions.Set(condition.FalseCondition(
+					condition.TLSInputReadyCondition,
+					condition.ErrorReason,
+					condition.SeverityWarning,
+					condition.TLSInputReadyWaitingMessage, secretName))
+				return ctrl.Result{}, nil
+			}
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				condition.TLSInputReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				condition.TLSInputErrorMessage,
+				err.Error()))
+			return ctrl.Result{}, err
+		}
+
+		if hash != "" {
+			configMapVars["region-"+secretName] = env.SetValue(hash)
+		}
+	}
+
 	// Validate metadata service cert secret
 	if instance.Spec.TLS.Enabled() {
 		hash, err := instance.Spec.TLS.ValidateCertSecret(ctx, helper, instance.Namespace)
@@ -812,13 +1172,9 @@ func (r *HorizonReconciler) reconcileNormal(ctx context.Context, instance *horiz
 	// Create ConfigMaps and Secrets required as input for the Service and calculate an overall hash of hashes
 	//
 
-	//
-	// create Configmap required for horizon input
-	// - %-scripts configmap holding scripts to e.g. bootstrap the service
-	// - %-config configmap holding minimal horizon config required to get the service up, user can add additional files to be added to the service
-	// - parameters which has passwords gets added from the OpenStack secret via the init container
-	//
-	err = r.generateServiceConfigMaps(ctx, instance, helper, &configMapVars, memcached)
+	// the SECRET_KEY Secret is an input of the session key prefix rendered in
+	// local_settings.py, hence it is created before the ConfigMaps
+	err = r.ensureHorizonSecret(ctx, instance, helper, userSecretKey, &configMapVars)
 	if err != nil {
 		instance.Status.Conditions.Set(condition.FalseCondition(
 			condition.ServiceConfigReadyCondition,
@@ -829,7 +1185,13 @@ func (r *HorizonReconciler) reconcileNormal(ctx context.Context, instance *horiz
 		return ctrl.Result{}, err
 	}
 
-	err = r.ensureHorizonSecret(ctx, instance, helper, &configMapVars)
+	//
+	// create Configmap required for horizon input
+	// - %-scripts configmap holding scripts to e.g. bootstrap the service
+	// - %-config configmap holding minimal horizon config required to get the service up, user can add additional files to be added to the service
+	// - parameters which has passwords gets added from the OpenStack secret via the init container
+	//
+	err = r.generateServiceConfigMaps(ctx, instance, helper, &configMapVars, memcached, redis, dbAccount)
 	if err != nil {
 		instance.Status.Conditions.Set(condition.FalseCondition(
 			condition.ServiceConfigReadyCondition,
@@ -853,18 +1215,39 @@ func (r *HorizonReconciler) reconcileNormal(ctx context.Context, instance *horiz
 
 	// Create ConfigMaps and Secrets - end
 
-	// Check if keystone service exists for watcher in the same namespace
-	enabledServices := make(map[string]string)
+	// Create the session tables before the Deployment uses them
+	if backend == horizonv1beta1.SessionBackendDatabase {
+		ctrlResult, err := r.reconcileDBSync(ctx, instance, helper, dbAccount, map[string]string{
+			common.AppSelector:   horizon.ServiceName,
+			common.OwnerSelector: instance.Name,
+		}, inputHash)
+		if err != nil || (ctrlResult != ctrl.Result{}) {
+			return ctrlResult, err
+		}
+	}
+
+	// Check which keystone services exist in the same namespace to resolve
+	// the dashboard plugins to enable
+	keystoneServices := make(map[string]bool)
 	for _, service := range keystoneServicesWatch {
 		keystoneService, err := keystonev1.GetKeystoneServiceWithName(ctx, helper, service, instance.Namespace)
 		if err != nil && !k8s_errors.IsNotFound(err) {
 			return ctrl.Result{}, err
 		}
-		enabledServices[service] = "no"
-		if keystoneService != nil {
-			enabledServices[service] = "yes"
-		}
-	}
+		keystoneServices[service] = err == nil && keystoneService != nil
+	}
+	enabledServices := horizon.GetEnabledServices(instance.Spec.Plugins, keystoneServices)
+	enabledPlugins := horizon.GetEnabledPlugins(enabledServices)
+	// the config is only rendered once the first set has been computed, the
+	// Event is not emitted until then as no Pod runs with a previous set
+	_, rendered := instance.Status.Hash[common.InputHashName]
+	if rendered && !slices.Equal(instance.Status.EnabledPlugins, enabledPlugins) {
+		// A KeystoneService appeared or disappeared, or spec.plugins changed:
+		// the horizon Pods are going to be rolled out with the new set
+		r.Recorder.Eventf(instance, corev1.EventTypeNormal, "EnabledPluginsChanged",
+			"Enabled dashboard plugins changed from %v to %v", instance.Status.EnabledPlugins, enabledPlugins)
+	}
+	instance.Status.EnabledPlugins = enabledPlugins
 	//
 
 	//
@@ -952,7 +1335,7 @@ func (r *HorizonReconciler) reconcileNormal(ctx context.Context, instance *horiz
 	//
 
 	// Define a new Deployment object
-	deplDef, err := horizon.Deployment(instance, inputHash, serviceLabels, serviceAnnotations, enabledServices, topology, memcached)
+	deplDef, err := horizon.Deployment(instance, inputHash, serviceLabels, serviceAnnotations, enabledServices, topology, memcached, dbAccount)
 	if err != nil {
 		Log.Error(err, "Deployment failed")
 		instance.Status.Conditions.Set(condition.FalseCondition(
@@ -964,6 +1347,22 @@ func (r *HorizonReconciler) reconcileNormal(ctx context.Context, instance *horiz
 		return ctrl.Result{}, err
 	}
 
+	// When autoscaling is enabled the HorizontalPodAutoscaler owns the number
+	// of replicas, keep the current one to not fight it
+	if instance.Spec.Autoscaling != nil {
+		replicas, err := r.getAutoscaledReplicas(ctx, instance, helper)
+		if err != nil {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				condition.DeploymentReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				condition.DeploymentReadyErrorMessage,
+				err.Error()))
+			return ctrl.Result{}, err
+		}
+		deplDef.Spec.Replicas = replicas
+	}
+
 	depl := deployment.NewDeployment(
 		deplDef,
 		time.Second*5,
@@ -994,8 +1393,10 @@ func (r *HorizonReconciler) reconcileNormal(ctx context.Context, instance *horiz
 
 	networkReady := false
 	var networkAttachmentStatus map[string][]string
-	// verify if network attachment matches expectations
-	if *instance.Spec.Replicas > 0 {
+	// verify if network attachment matches expectations, the replicas of
+	// the Deployment are the ones set by the HorizontalPodAutoscaler when
+	// autoscaling is enabled
+	if ptr.Deref(deplDef.Spec.Replicas, 0) > 0 {
 		networkReady, networkAttachmentStatus, err = nad.VerifyNetworkStatusFromAnnotation(
 			ctx,
 			helper,
@@ -1035,6 +1436,19 @@ func (r *HorizonReconciler) reconcileNormal(ctx context.Context, instance *horiz
 	// by comparing it with the ObservedGeneration.
 	if deployment.IsReady(deploy) {
 		instance.Status.Conditions.MarkTrue(condition.DeploymentReadyCondition, condition.DeploymentReadyMessage)
+		// the Deployment named after the instance serves the dashboard, the
+		// objects named after the service can go
+		err = r.deleteLegacyObjects(ctx, instance, helper)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	} else if msg, stuck := progressDeadlineExceeded(deploy); stuck {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.DeploymentReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.DeploymentReadyErrorMessage,
+			msg))
 	} else {
 		instance.Status.Conditions.Set(condition.FalseCondition(
 			condition.DeploymentReadyCondition,
@@ -1044,15 +1458,61 @@ func (r *HorizonReconciler) reconcileNormal(ctx context.Context, instance *horiz
 	}
 	// create Deployment - end
 
-	// We reached the end of the Reconcile, update the Ready condition based on
-	// the sub conditions
-	if instance.Status.Conditions.AllSubConditionIsTrue() {
-		instance.Status.Conditions.MarkTrue(
-			condition.ReadyCondition, condition.ReadyMessage)
+	// create or delete the HorizontalPodAutoscaler
+	err = r.reconcileAutoscaling(ctx, instance, helper, serviceLabels)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			horizonv1beta1.HorizonAutoscalingReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			horizonv1beta1.HorizonAutoscalingReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
 	}
-	Log.Info("Reconciled Service successfully")
-	return ctrl.Result{}, nil
-}
+
+	// create or delete the PodDisruptionBudget, a single replica can't be
+	// protected without blocking the node drains
+	replicas := ptr.Deref(deplDef.Spec.Replicas, 0)
+	err = r.reconcilePodDisruptionBudget(ctx, instance, helper, serviceLabels, replicas)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			horizonv1beta1.HorizonPodDisruptionBudgetReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			horizonv1beta1.HorizonPodDisruptionBudgetReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+	if replicas <= 1 {
+		instance.Status.Conditions.MarkTrue(
+			horizonv1beta1.HorizonPodDisruptionBudgetReadyCondition,
+			horizonv1beta1.HorizonPodDisruptionBudgetNotRequiredMessage, replicas)
+	} else {
+		instance.Status.Conditions.MarkTrue(
+			horizonv1beta1.HorizonPodDisruptionBudgetReadyCondition,
+			horizonv1beta1.HorizonPodDisruptionBudgetReadyMessage)
+	}
+
+	// remove finalizers from unused MariaDBAccount records, e.g. the one used
+	// before the databaseAccount was changed
+	if dbAccount != nil {
+		err = mariadbv1.DeleteUnusedMariaDBAccountFinalizers(
+			ctx, helper, horizon.GetDatabaseCRName(instance), dbAccount.Name, instance.Namespace)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	// We reached the end of the Reconcile, update the Ready condition based on
+	// the sub conditions
+	if instance.Status.Conditions.AllSubConditionIsTrue() {
+		instance.Status.Conditions.MarkTrue(
+			condition.ReadyCondition, condition.ReadyMessage)
+	}
+	Log.Info("Reconciled Service successfully")
+	// come back for the next automatic rotation of SECRET_KEY
+	return ctrl.Result{RequeueAfter: horizon.GetSecretKeyRotationRequeue(instance, time.Now())}, nil
+}
 
 // generateServiceConfigMaps - create configmaps which hold scripts and service configuration
 func (r *HorizonReconciler) generateServiceConfigMaps(
@@ -1061,6 +1521,8 @@ func (r *HorizonReconciler) generateServiceConfigMaps(
 	h *helper.Helper,
 	envVars *map[string]env.Setter,
 	mc *memcachedv1.Memcached,
+	redis *horizon.RedisEndpoint,
+	dbAccount *mariadbv1.MariaDBAccount,
 ) error {
 	//
 	// create Configmap/Secret required for horizon input
@@ -1072,18 +1534,38 @@ func (r *HorizonReconciler) generateServiceConfigMaps(
 	cmLabels := labels.GetLabels(instance, labels.GetGroupLabel(horizon.ServiceName), map[string]string{})
 
 	// customData hold any customization for the service.
-	// custom.conf is going to /etc/<service>/<service>.conf.d
-	// all other files get placed into /etc/<service> to allow overwrite of e.g. logging.conf or policy.json
-	// TODO: make sure custom.conf can not be overwritten
+	// 9999_custom_settings.py is going to /etc/openstack-dashboard/local_settings.d,
+	// the DefaultConfigOverwrite files are placed according to their name (see
+	// horizon.GetConfigOverwriteFiles) and the webhook prevents them from
+	// replacing the files rendered by the operator
 	customData := map[string]string{"9999_custom_settings.py": instance.Spec.CustomServiceConfig}
 	maps.Copy(customData, instance.Spec.DefaultConfigOverwrite)
 
+	overwriteFiles, unknownFiles := horizon.GetConfigOverwriteFiles(instance.Spec.DefaultConfigOverwrite)
+	if len(unknownFiles) > 0 {
+		// The webhook rejects the unknown keys, they are only found when it
+		// is bypassed. They are not placed in the Pod, but they don't prevent
+		// the Deployment from being updated
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			horizonv1beta1.HorizonConfigOverwriteReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			horizonv1beta1.HorizonConfigOverwriteReadyErrorMessage,
+			strings.Join(unknownFiles, ", ")))
+	} else {
+		instance.Status.Conditions.MarkTrue(
+			horizonv1beta1.HorizonConfigOverwriteReadyCondition,
+			horizonv1beta1.HorizonConfigOverwriteReadyMessage)
+	}
+
 	keystoneAPI, err := keystonev1.GetKeystoneAPI(ctx, h, instance.Namespace, map[string]string{})
 	if err != nil {
 		return err
 	}
 
-	authURL, err := keystoneAPI.GetEndpoint(endpoint.EndpointInternal)
+	// OPENSTACK_KEYSTONE_URL, on the internal interface unless the user
+	// selected another one
+	authURL, err := keystoneAPI.GetEndpoint(endpoint.Endpoint(instance.Spec.GetKeystoneInterface()))
 	if err != nil {
 		return err
 	}
@@ -1094,16 +1576,67 @@ func (r *HorizonReconciler) generateServiceConfigMaps(
 	}
 
 	templateParameters := map[string]any{
-		"keystoneURL":         authURL,
-		"horizonEndpoint":     instance.Status.Endpoint,
-		"horizonEndpointHost": url.Host,
-		"memcachedServers":    mc.GetMemcachedServerListQuotedString(),
-		"memcachedTLS":        mc.GetMemcachedTLSSupport(),
-		"ServerName":          fmt.Sprintf("%s.%s.svc", horizon.ServiceName, instance.Namespace),
-		"Port":                horizon.HorizonPort,
-		"TLS":                 false,
-		"isPublicHTTPS":       url.Scheme == "https",
-		"LogFile":             horizon.LogFile,
+		"keystoneURL":           authURL,
+		"horizonEndpoint":       instance.Status.Endpoint,
+		"sessionBackend":        string(instance.Spec.GetSessionBackend()),
+		"ServerName":            fmt.Sprintf("%s.%s.svc", instance.Name, instance.Namespace),
+		"Port":                  horizon.HorizonPort,
+		"TLS":                   false,
+		"isPublicHTTPS":         url.Scheme == "https",
+		"LogFile":               horizon.LogFile,
+		"settings":              horizon.GetSettings(instance.Spec.Settings),
+		"endpointType":          horizon.GetEndpointType(instance.Spec.GetCatalogInterface()),
+		"secondaryEndpointType": horizon.GetEndpointType(instance.Spec.GetSecondaryCatalogInterface()),
+		"disabledDashboard":     horizon.GetDisabledDashboard(instance.Spec.GetProfile()),
+		"profileEnabledFile":    horizon.GetProfileEnabledFileDest(),
+		"webRoot":               instance.Spec.GetWebRoot(),
+		"allowedHosts":          horizon.GetHostnames(url, instance.Spec.AdditionalHostnames),
+		"csrfTrustedOrigins":    horizon.GetCSRFTrustedOrigins(url, instance.Spec.AdditionalHostnames),
+	}
+	instance.Status.Hostnames = horizon.GetHostnames(url, instance.Spec.AdditionalHostnames)
+
+	// prefix of the cached session keys, see horizon.GetSessionKeyPrefix
+	secretKey, _, err := oko_secret.GetSecret(ctx, h, instance.Name, instance.Namespace)
+	if err != nil {
+		return err
+	}
+	// the key replaced by the last rotation is accepted in SECRET_KEY_FALLBACKS
+	templateParameters["secretKeyFallback"] = len(secretKey.Data[horizon.PreviousSecretKeySelector]) != 0
+	templateParameters["sessionKeyPrefix"], err = horizon.GetSessionKeyPrefix(instance, authURL)
+	if err != nil {
+		return err
+	}
+
+	// render AVAILABLE_REGIONS, and OPENSTACK_SSL_CACERT when the CA bundles
+	// of the regions are concatenated with the one of the pod
+	regions, err := r.getRegions(ctx, instance, h, authURL)
+	if err != nil {
+		return err
+	}
+	templateParameters["regions"] = horizon.GetAvailableRegions(regions)
+	if len(horizon.GetRegionCABundleSecretNames(instance.Spec.Regions)) > 0 {
+		templateParameters["regionCABundle"] = horizon.RegionCABundleFile
+	}
+
+	// place the DefaultConfigOverwrite files in horizon.json
+	templateParameters["configOverwriteFiles"] = overwriteFiles
+
+	// place the service policy files in horizon.json and POLICY_FILES
+	templateParameters["policyFiles"] = horizon.GetPolicyFiles(instance.Spec.Policies)
+
+	// create WebSSO template parameters
+	if instance.Spec.SSO != nil {
+		// The browser is redirected to Keystone during the WebSSO flow, hence
+		// the public endpoint is used
+		publicAuthURL, err := keystoneAPI.GetEndpoint(endpoint.EndpointPublic)
+		if err != nil {
+			return err
+		}
+		templateParameters["keystonePublicURL"] = publicAuthURL
+		templateParameters["sso"] = horizon.GetWebSSO(instance.Spec.SSO)
+		if idp := instance.Spec.SSO.GetOIDCProvider(); idp != nil {
+			templateParameters["ssoOIDC"] = idp
+		}
 	}
 
 	// create httpd tls template parameters
@@ -1114,8 +1647,35 @@ func (r *HorizonReconciler) generateServiceConfigMaps(
 		templateParameters["SSLCertificateKeyFile"] = fmt.Sprintf("/etc/pki/tls/private/%s.key", horizon.ServiceName)
 	}
 
+	// Set the parameters of the session backend, mc and redis are nil when
+	// not used
+	if mc != nil {
+		templateParameters["memcachedServers"] = mc.GetMemcachedServerListQuotedString()
+		templateParameters["memcachedTLS"] = mc.GetMemcachedTLSSupport()
+	}
+	if redis != nil {
+		templateParameters["redisURL"] = redis.URL
+		templateParameters["redisCACert"] = redis.CACert
+	}
+	if dbAccount != nil {
+		templateParameters["databaseHost"] = instance.Status.DatabaseHostname
+		templateParameters["databaseName"] = horizon.GetDatabaseName(instance)
+		templateParameters["databaseUser"] = dbAccount.Spec.UserName
+
+		db, err := mariadbv1.GetDatabaseByNameAndAccount(
+			ctx, h, horizon.GetDatabaseCRName(instance), dbAccount.Name, instance.Namespace)
+		if err != nil {
+			return err
+		}
+		var tlsCfg *tls.Service
+		if instance.Spec.TLS.CaBundleSecretName != "" {
+			tlsCfg = &tls.Service{}
+		}
+		customData["my.cnf"] = db.GetDatabaseClientConfig(tlsCfg)
+	}
+
 	// Set Memcached MTLS parameters if required
-	if mc.GetMemcachedMTLSSecret() != "" {
+	if mc != nil && mc.GetMemcachedMTLSSecret() != "" {
 		templateParameters["memcachedMTLS"] = true
 		templateParameters["memcachedAuthCa"] = fmt.Sprint(memcachedv1.CaMountPath())
 		templateParameters["memcachedAuthCert"] = fmt.Sprint(memcachedv1.CertMountPath())
@@ -1170,11 +1730,16 @@ func (r *HorizonReconciler) createHashOfInputHashes(
 	return hash, changed, nil
 }
 
-// ensureHorizonSecret - Creates a k8s secret to hold the Horizon SECRET_KEY.
+// ensureHorizonSecret - Creates a k8s secret to hold the Horizon SECRET_KEY,
+// either the userSecretKey supplied through spec.secret or a generated one. A
+// generated key is rotated according to spec.secretKeyRotation and the
+// rotation annotation, a supplied one when it changes. The Secret hash is
+// added to the vars map to roll the Deployment out when the key changes
 func (r *HorizonReconciler) ensureHorizonSecret(
 	ctx context.Context,
 	instance *horizonv1beta1.Horizon,
 	h *helper.Helper,
+	userSecretKey string,
 	envVars *map[string]env.Setter,
 ) error {
 	Log := r.GetLogger(ctx)
@@ -1182,101 +1747,1041 @@ func (r *HorizonReconciler) ensureHorizonSecret(
 	//
 	// check if secret already exist
 	//
-	scrt, _, err := oko_secret.GetSecret(ctx, h, horizon.ServiceName, instance.Namespace)
+	scrt, hash, err := oko_secret.GetSecret(ctx, h, instance.Name, instance.Namespace)
 	if err != nil && !k8s_errors.IsNotFound(err) {
 		return err
 	}
+
+	now := time.Now()
+	var secretData map[string]string
+	rotated := false
 	if k8s_errors.IsNotFound(err) || !validateHorizonSecret(scrt) {
-		Log.Info("Creating Horizon Secret")
+		// keep the keys of the Secret named after the service, used before
+		// the child objects were named after the instance, so that the
+		// sessions survive the migration
+		secretData, err = r.getLegacySecretData(ctx, instance, h)
+		if err != nil {
+			return err
+		}
+		if secretData != nil {
+			Log.Info(fmt.Sprintf("Migrating Horizon Secret %s to %s", horizon.ServiceName, instance.Name))
+		} else {
+			Log.Info("Creating Horizon Secret")
+			secretData = map[string]string{}
+		}
+	} else {
+		if instance.Status.SecretKeyLastRotated == nil {
+			// the Secret was created before its rotation was tracked
+			instance.Status.SecretKeyLastRotated = ptr.To(scrt.CreationTimestamp)
+		}
+		currentKey := string(scrt.Data[horizon.SecretKeySelector])
+		if (userSecretKey != "" && userSecretKey != currentKey) ||
+			(userSecretKey == "" && horizon.IsSecretKeyRotationDue(instance, instance.Status.SecretKeyLastRotated.Time, now)) {
+			Log.Info("Rotating Horizon SECRET_KEY")
+			// keep the current key as SECRET_KEY_FALLBACKS until the next
+			// rotation
+			secretData = map[string]string{
+				horizon.PreviousSecretKeySelector: currentKey,
+			}
+			rotated = true
+		}
+	}
 
+	if secretData == nil {
+		(*envVars)[instance.Name] = env.SetValue(hash)
+		return nil
+	}
+
+	migrated := secretData[horizon.SecretKeySelector] != ""
+	switch {
+	case userSecretKey != "":
+		secretData[horizon.SecretKeySelector] = userSecretKey
+	case !migrated:
 		secretKey, err := util.GeneratePassword(50)
 		if err != nil {
 			return fmt.Errorf("generating Horizon SECRET_KEY: %w", err)
 		}
+		secretData[horizon.SecretKeySelector] = secretKey
+	}
 
-		// Create k8s secret to store Horizon Secret
-		tmpl := []util.Template{
-			{
-				Name:       horizon.ServiceName,
-				Namespace:  instance.Namespace,
-				Type:       util.TemplateTypeNone,
-				CustomData: map[string]string{"horizon-secret": secretKey},
-				Labels:     Labels,
-			},
+	// Create k8s secret to store Horizon Secret
+	tmpl := []util.Template{
+		{
+			Name:       instance.Name,
+			Namespace:  instance.Namespace,
+			Type:       util.TemplateTypeNone,
+			CustomData: secretData,
+			Labels:     Labels,
+		},
+	}
+
+	err = oko_secret.EnsureSecrets(ctx, h, instance, tmpl, envVars)
+	if err != nil {
+		return err
+	}
+
+	if !migrated || instance.Status.SecretKeyLastRotated == nil {
+		instance.Status.SecretKeyLastRotated = &metav1.Time{Time: now}
+	}
+	instance.Status.SecretKeyRotationTrigger = instance.Annotations[horizonv1beta1.SecretKeyRotationAnnotation]
+	if rotated {
+		r.Recorder.Event(instance, corev1.EventTypeNormal, "SecretKeyRotated",
+			"SECRET_KEY rotated, the previous key is kept in SECRET_KEY_FALLBACKS")
+	}
+
+	return nil
+}
+
+// getLegacySecretData - returns the content of the SECRET_KEY Secret named
+// after the service and controlled by the instance, nil when there is none
+func (r *HorizonReconciler) getLegacySecretData(
+	ctx context.Context,
+	instance *horizonv1beta1.Horizon,
+	h *helper.Helper,
+) (map[string]string, error) {
+	if instance.Name == horizon.ServiceName {
+		return nil, nil
+	}
+	scrt, _, err := oko_secret.GetSecret(ctx, h, horizon.ServiceName, instance.Namespace)
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			return nil, nil
 		}
+		return nil, err
+	}
+	if !metav1.IsControlledBy(scrt, instance) || !validateHorizonSecret(scrt) {
+		return nil, nil
+	}
+	data := map[string]string{}
+	for k, v := range scrt.Data {
+		data[k] = string(v)
+	}
+	return data, nil
+}
+
+// deleteLegacyObjects - deletes the child objects named after the service,
+// used before they were named after the instance, once the Deployment named
+// after the instance is ready. Only the objects controlled by the instance are
+// deleted
+func (r *HorizonReconciler) deleteLegacyObjects(
+	ctx context.Context,
+	instance *horizonv1beta1.Horizon,
+	h *helper.Helper,
+) error {
+	Log := r.GetLogger(ctx)
+	if instance.Name == horizon.ServiceName {
+		return nil
+	}
 
-		err = oko_secret.EnsureSecrets(ctx, h, instance, tmpl, envVars)
+	legacyObjects := []client.Object{
+		&appsv1.Deployment{},
+		&corev1.Service{},
+		&corev1.Secret{},
+		&autoscalingv2.HorizontalPodAutoscaler{},
+		&policyv1.PodDisruptionBudget{},
+	}
+	for _, obj := range legacyObjects {
+		err := h.GetClient().Get(ctx, types.NamespacedName{Name: horizon.ServiceName, Namespace: instance.Namespace}, obj)
 		if err != nil {
+			if k8s_errors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if !metav1.IsControlledBy(obj, instance) {
+			continue
+		}
+		if err := h.GetClient().Delete(ctx, obj); err != nil && !k8s_errors.IsNotFound(err) {
 			return err
 		}
+		Log.Info(fmt.Sprintf("Deleted legacy %T %s", obj, obj.GetName()))
 	}
-
 	return nil
 }
 
-func validateHorizonSecret(secret *corev1.Secret) bool {
-	return len(secret.Data["horizon-secret"]) != 0
-}
+// verifySSOSecrets - checks the Secrets holding the client credentials of the
+// SSO identity providers and adds their hash to the vars map
+func (r *HorizonReconciler) verifySSOSecrets(
+	ctx context.Context,
+	instance *horizonv1beta1.Horizon,
+	h *helper.Helper,
+	envVars *map[string]env.Setter,
+) (ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+	if instance.Spec.SSO == nil {
+		return ctrl.Result{}, nil
+	}
 
-func configureHorizonRbac(ctx context.Context, helper *helper.Helper, instance *horizonv1beta1.Horizon) (rbacResult ctrl.Result, err error) {
-	rbacRules := []rbacv1.PolicyRule{
-		{
-			APIGroups:     []string{"security.openshift.io"},
-			ResourceNames: []string{"anyuid", "hostmount-anyuid"},
-			Resources:     []string{"securitycontextconstraints"},
-			Verbs:         []string{"use"},
-		},
+	for _, idp := range instance.Spec.SSO.IdentityProviders {
+		if idp.CredentialsSecret == "" {
+			continue
+		}
+		scrt, hash, err := oko_secret.GetSecret(ctx, h, idp.CredentialsSecret, instance.Namespace)
+		if err != nil {
+			if k8s_errors.IsNotFound(err) {
+				Log.Info(fmt.Sprintf("SSO credentials secret %s not found", idp.CredentialsSecret))
+				instance.Status.Conditions.Set(condition.FalseCondition(
+					condition.InputReadyCondition,
+					condition.ErrorReason,
+					condition.SeverityWarning,
+					condition.InputReadyWaitingMessage))
+				return ctrl.Result{RequeueAfter: time.Second * 10}, nil
+			}
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				condition.InputReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				condition.InputReadyErrorMessage,
+				err.Error()))
+			return ctrl.Result{}, err
+		}
+
+		// the mod_auth_openidc configuration consumes both the client
+		// secret and the crypto passphrase
+		if idp.ProviderMetadataURL != "" {
+			for _, key := range []string{
+				horizonv1beta1.SSOClientSecretSelector,
+				horizonv1beta1.SSOCryptoPassphraseSelector,
+			} {
+				if len(scrt.Data[key]) == 0 {
+					instance.Status.Conditions.Set(condition.FalseCondition(
+						condition.InputReadyCondition,
+						condition.ErrorReason,
+						condition.SeverityWarning,
+						condition.InputReadyErrorMessage,
+						fmt.Sprintf("%s not found in secret %s", key, scrt.Name)))
+					// the Secret is watched, no need to requeue
+					return ctrl.Result{}, nil
+				}
+			}
+		}
+		(*envVars)[scrt.Name] = env.SetValue(hash)
 	}
-	return common_rbac.ReconcileRbac(ctx, helper, instance, rbacRules)
+
+	return ctrl.Result{}, nil
 }
 
-// ensureNAD - this function gets NADs based on the string[] passed as input
-// and produces the required Annotation for the Horizon component
-func (r *HorizonReconciler) ensureNAD(
+// verifyPolicyConfigMaps - checks the ConfigMaps holding the service policy
+// files and adds their hash to the vars map
+func (r *HorizonReconciler) verifyPolicyConfigMaps(
 	ctx context.Context,
 	instance *horizonv1beta1.Horizon,
-	nAttach []string,
-	helper *helper.Helper,
-) (map[string]string, ctrl.Result, error) {
-	var serviceAnnotations map[string]string
-	var err error
-	// Iterate over the []networkattachment, get the corresponding NAD and create
-	// the required annotation
-	nadList := []networkv1.NetworkAttachmentDefinition{}
-	for _, netAtt := range nAttach {
-		nad, err := nad.GetNADWithName(ctx, helper, netAtt, helper.GetBeforeObject().GetNamespace())
+	h *helper.Helper,
+	envVars *map[string]env.Setter,
+) (ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+
+	for _, p := range horizon.GetPolicyFiles(instance.Spec.Policies) {
+		cm, hash, err := configmap.GetConfigMapAndHashWithName(ctx, h, p.ConfigMapName, instance.Namespace)
 		if err != nil {
 			if k8s_errors.IsNotFound(err) {
-				// Since the net-attach-def CR should have been manually created by the user and referenced in the spec,
-				// we treat this as a warning because it means that the service will not be able to start.
-				helper.GetLogger().Info(fmt.Sprintf("network-attachment-definition %s not found", netAtt))
-
+				Log.Info(fmt.Sprintf("%s policy configmap %s not found", p.Service, p.ConfigMapName))
 				instance.Status.Conditions.Set(condition.FalseCondition(
-					condition.NetworkAttachmentsReadyCondition,
+					condition.InputReadyCondition,
 					condition.ErrorReason,
 					condition.SeverityWarning,
-					condition.NetworkAttachmentsReadyWaitingMessage,
-					netAtt))
-				return serviceAnnotations, ctrl.Result{RequeueAfter: time.Second * 10}, nil
+					condition.InputReadyWaitingMessage))
+				return ctrl.Result{RequeueAfter: time.Second * 10}, nil
 			}
 			instance.Status.Conditions.Set(condition.FalseCondition(
-				condition.NetworkAttachmentsReadyCondition,
+				condition.InputReadyCondition,
 				condition.ErrorReason,
 				condition.SeverityWarning,
-				condition.NetworkAttachmentsReadyErrorMessage,
+				condition.InputReadyErrorMessage,
 				err.Error()))
-			return serviceAnnotations, ctrl.Result{RequeueAfter: time.Second * 10}, nil
+			return ctrl.Result{}, err
 		}
 
-		if nad != nil {
-			nadList = append(nadList, *nad)
+		// the ConfigMap key is mounted as a file, the Pod can't start
+		// if it's missing
+		if _, ok := cm.Data[p.Key]; !ok {
+			err := fmt.Errorf("%w: %s not found in configmap %s", ErrPolicyConfigMap, p.Key, cm.Name)
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				condition.InputReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				condition.InputReadyErrorMessage,
+				err.Error()))
+			return ctrl.Result{}, err
 		}
+		(*envVars)["policy-"+cm.Name] = env.SetValue(hash)
 	}
-	// Create NetworkAnnotations
-	serviceAnnotations, err = nad.EnsureNetworksAnnotation(nadList)
+
+	return ctrl.Result{}, nil
+}
+
+// getMemcached - returns the Memcached instance storing the sessions, once it
+// is ready
+func (r *HorizonReconciler) getMemcached(
+	ctx context.Context,
+	instance *horizonv1beta1.Horizon,
+	h *helper.Helper,
+) (*memcachedv1.Memcached, ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+
+	memcached, err := memcachedv1.GetMemcachedByName(ctx, h, instance.Spec.MemcachedInstance, instance.Namespace)
 	if err != nil {
-		return serviceAnnotations, ctrl.Result{}, fmt.Errorf("failed create network annotation from %s: %w",
-			nAttach, err)
+		if k8s_errors.IsNotFound(err) {
+			// Memcached should be automatically created by the encompassing OpenStackControlPlane,
+			// but we don't propagate its name into the "memcachedInstance" field of other sub-resources,
+			// so if it is missing at this point, it *could* be because there's a mismatch between the
+			// name of the Memcached CR and the name of the Memcached instance referenced by this CR.
+			// Since that situation would block further reconciliation, we treat it as a warning.
+			Log.Info(fmt.Sprintf("memcached %s not found", instance.Spec.MemcachedInstance))
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				condition.MemcachedReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				condition.MemcachedReadyWaitingMessage))
+			return nil, ctrl.Result{RequeueAfter: time.Second * 10}, nil
+		}
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.MemcachedReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.MemcachedReadyErrorMessage,
+			err.Error()))
+		return nil, ctrl.Result{}, err
 	}
-	return serviceAnnotations, ctrl.Result{}, err
+
+	if !memcached.IsReady() {
+		Log.Info(fmt.Sprintf("memcached %s is not ready", memcached.Name))
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.MemcachedReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			condition.MemcachedReadyWaitingMessage))
+		return nil, ctrl.Result{RequeueAfter: time.Second * 10}, nil
+	}
+	// Mark the Memcached Service as Ready if we get to this point with no errors
+	instance.Status.Conditions.MarkTrue(
+		condition.MemcachedReadyCondition, condition.MemcachedReadyMessage)
+	return memcached, ctrl.Result{}, nil
+}
+
+// getRedis - returns the connection settings of the Redis instance storing
+// the sessions, once it is ready
+func (r *HorizonReconciler) getRedis(
+	ctx context.Context,
+	instance *horizonv1beta1.Horizon,
+	h *helper.Helper,
+) (*horizon.RedisEndpoint, ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+
+	redis := &redisv1.Redis{}
+	err := h.GetClient().Get(ctx, types.NamespacedName{Name: instance.Spec.RedisInstance, Namespace: instance.Namespace}, redis)
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			Log.Info(fmt.Sprintf("redis %s not found", instance.Spec.RedisInstance))
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				horizonv1beta1.HorizonRedisReadyCondition,
+				condition.RequestedReason,
+				condition.SeverityInfo,
+				horizonv1beta1.HorizonRedisReadyWaitingMessage,
+				instance.Spec.RedisInstance))
+			return nil, ctrl.Result{RequeueAfter: time.Second * 10}, nil
+		}
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			horizonv1beta1.HorizonRedisReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			horizonv1beta1.HorizonRedisReadyErrorMessage,
+			err.Error()))
+		return nil, ctrl.Result{}, err
+	}
+
+	if !redis.IsReady() {
+		Log.Info(fmt.Sprintf("redis %s is not ready", redis.Name))
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			horizonv1beta1.HorizonRedisReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			horizonv1beta1.HorizonRedisReadyWaitingMessage,
+			redis.Name))
+		return nil, ctrl.Result{RequeueAfter: time.Second * 10}, nil
+	}
+
+	// the certificate of Redis is verified with the CA bundle of the pods,
+	// the Horizon and Redis watches reconcile once it is provided
+	if redis.Spec.TLS.Enabled() && instance.Spec.TLS.CaBundleSecretName == "" {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			horizonv1beta1.HorizonRedisReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityError,
+			horizonv1beta1.HorizonRedisReadyErrorMessage,
+			fmt.Sprintf("redis %s has TLS enabled, tls.caBundleSecretName is required to verify its certificate",
+				redis.Name)))
+		return nil, ctrl.Result{}, nil
+	}
+
+	svc := &corev1.Service{}
+	err = h.GetClient().Get(ctx, types.NamespacedName{Name: redis.Name, Namespace: redis.Namespace}, svc)
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			Log.Info(fmt.Sprintf("service of redis %s not found", redis.Name))
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				horizonv1beta1.HorizonRedisReadyCondition,
+				condition.RequestedReason,
+				condition.SeverityInfo,
+				horizonv1beta1.HorizonRedisReadyWaitingMessage,
+				redis.Name))
+			return nil, ctrl.Result{RequeueAfter: time.Second * 10}, nil
+		}
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			horizonv1beta1.HorizonRedisReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			horizonv1beta1.HorizonRedisReadyErrorMessage,
+			err.Error()))
+		return nil, ctrl.Result{}, err
+	}
+
+	endpoint := horizon.GetRedisEndpoint(redis, svc)
+	instance.Status.Conditions.MarkTrue(
+		horizonv1beta1.HorizonRedisReadyCondition, horizonv1beta1.HorizonRedisReadyMessage)
+	return &endpoint, ctrl.Result{}, nil
+}
+
+// getAutoscaledReplicas - returns the replicas of the existing Deployment, set
+// by the HorizontalPodAutoscaler, or the autoscaling minReplicas when the
+// Deployment doesn't exist yet
+func (r *HorizonReconciler) getAutoscaledReplicas(
+	ctx context.Context,
+	instance *horizonv1beta1.Horizon,
+	h *helper.Helper,
+) (*int32, error) {
+	depl := &appsv1.Deployment{}
+	err := h.GetClient().Get(ctx, types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, depl)
+	if err != nil {
+		if k8s_errors.IsNotFound(err) {
+			return instance.Spec.Autoscaling.MinReplicas, nil
+		}
+		return nil, err
+	}
+	return depl.Spec.Replicas, nil
+}
+
+// reconcileAutoscaling - creates or updates the HorizontalPodAutoscaler when
+// autoscaling is enabled, deletes it otherwise, and reports its state in the
+// HorizonAutoscalingReady condition
+func (r *HorizonReconciler) reconcileAutoscaling(
+	ctx context.Context,
+	instance *horizonv1beta1.Horizon,
+	h *helper.Helper,
+	serviceLabels map[string]string,
+) error {
+	Log := r.GetLogger(ctx)
+
+	if instance.Spec.Autoscaling == nil {
+		hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+		err := h.GetClient().Get(ctx, types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, hpa)
+		if err != nil && !k8s_errors.IsNotFound(err) {
+			return err
+		}
+		if err == nil && metav1.IsControlledBy(hpa, instance) {
+			if err := h.GetClient().Delete(ctx, hpa); err != nil && !k8s_errors.IsNotFound(err) {
+				return err
+			}
+			Log.Info(fmt.Sprintf("HorizontalPodAutoscaler %s deleted", hpa.Name))
+		}
+		instance.Status.Conditions.Remove(horizonv1beta1.HorizonAutoscalingReadyCondition)
+		return nil
+	}
+
+	hpaDef := horizon.HorizontalPodAutoscaler(instance, serviceLabels)
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      hpaDef.Name,
+			Namespace: hpaDef.Namespace,
+		},
+	}
+	op, err := controllerutil.CreateOrPatch(ctx, h.GetClient(), hpa, func() error {
+		hpa.Labels = util.MergeStringMaps(hpa.Labels, hpaDef.Labels)
+		hpa.Spec = hpaDef.Spec
+		return controllerutil.SetControllerReference(instance, hpa, h.GetScheme())
+	})
+	if err != nil {
+		return err
+	}
+	if op != controllerutil.OperationResultNone {
+		Log.Info(fmt.Sprintf("HorizontalPodAutoscaler %s - %s", hpa.Name, op))
+	}
+
+	// AbleToScale and ScalingActive are set by the HPA controller, report
+	// them when they prevent the Deployment from being scaled
+	for _, c := range hpa.Status.Conditions {
+		if (c.Type == autoscalingv2.AbleToScale || c.Type == autoscalingv2.ScalingActive) &&
+			c.Status == corev1.ConditionFalse {
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				horizonv1beta1.HorizonAutoscalingReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				horizonv1beta1.HorizonAutoscalingReadyErrorMessage,
+				fmt.Sprintf("%s: %s", c.Reason, c.Message)))
+			return nil
+		}
+	}
+	instance.Status.Conditions.MarkTrue(
+		horizonv1beta1.HorizonAutoscalingReadyCondition,
+		horizonv1beta1.HorizonAutoscalingReadyMessage,
+		hpa.Status.CurrentReplicas,
+		hpa.Status.DesiredReplicas)
+	return nil
+}
+
+// reconcilePodDisruptionBudget - creates or updates the PodDisruptionBudget of
+// the horizon pods when more than one replica is requested, deletes it
+// otherwise
+func (r *HorizonReconciler) reconcilePodDisruptionBudget(
+	ctx context.Context,
+	instance *horizonv1beta1.Horizon,
+	h *helper.Helper,
+	serviceLabels map[string]string,
+	replicas int32,
+) error {
+	Log := r.GetLogger(ctx)
+
+	if replicas <= 1 {
+		pdb := &policyv1.PodDisruptionBudget{}
+		err := h.GetClient().Get(ctx, types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, pdb)
+		if err != nil && !k8s_errors.IsNotFound(err) {
+			return err
+		}
+		if err == nil && metav1.IsControlledBy(pdb, instance) {
+			if err := h.GetClient().Delete(ctx, pdb); err != nil && !k8s_errors.IsNotFound(err) {
+				return err
+			}
+			Log.Info(fmt.Sprintf("PodDisruptionBudget %s deleted", pdb.Name))
+		}
+		return nil
+	}
+
+	pdbDef := horizon.PodDisruptionBudget(instance, serviceLabels)
+	pdb := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pdbDef.Name,
+			Namespace: pdbDef.Namespace,
+		},
+	}
+	op, err := controllerutil.CreateOrPatch(ctx, h.GetClient(), pdb, func() error {
+		pdb.Labels = util.MergeStringMaps(pdb.Labels, pdbDef.Labels)
+		pdb.Spec = pdbDef.Spec
+		return controllerutil.SetControllerReference(instance, pdb, h.GetScheme())
+	})
+	if err != nil {
+		return err
+	}
+	if op != controllerutil.OperationResultNone {
+		Log.Info(fmt.Sprintf("PodDisruptionBudget %s - %s", pdb.Name, op))
+	}
+	return nil
+}
+
+// progressDeadlineExceeded - returns true, with the reported message, when the
+// rollout of the Deployment didn't progress within ProgressDeadlineSeconds
+func progressDeadlineExceeded(deploy *appsv1.Deployment) (string, bool) {
+	for _, c := range deploy.Status.Conditions {
+		if c.Type == appsv1.DeploymentProgressing &&
+			c.Status == corev1.ConditionFalse &&
+			c.Reason == "ProgressDeadlineExceeded" {
+			return c.Message, true
+		}
+	}
+	return "", false
+}
+
+func validateHorizonSecret(secret *corev1.Secret) bool {
+	return len(secret.Data[horizon.SecretKeySelector]) != 0
+}
+
+func configureHorizonRbac(ctx context.Context, helper *helper.Helper, instance *horizonv1beta1.Horizon) (rbacResult ctrl.Result, err error) {
+	rbacRules := []rbacv1.PolicyRule{
+		{
+			APIGroups:     []string{"security.openshift.io"},
+			ResourceNames: []string{"anyuid", "hostmount-anyuid"},
+			Resources:     []string{"securitycontextconstraints"},
+			Verbs:         []string{"use"},
+		},
+	}
+	return common_rbac.ReconcileRbac(ctx, helper, instance, rbacRules)
+}
+
+// ensureNAD - this function gets NADs based on the string[] passed as input
+// and produces the required Annotation for the Horizon component
+func (r *HorizonReconciler) ensureNAD(
+	ctx context.Context,
+	instance *horizonv1beta1.Horizon,
+	nAttach []string,
+	helper *helper.Helper,
+) (map[string]string, ctrl.Result, error) {
+	var serviceAnnotations map[string]string
+	var err error
+	// Iterate over the []networkattachment, get the corresponding NAD and create
+	// the required annotation
+	nadList := []networkv1.NetworkAttachmentDefinition{}
+	for _, netAtt := range nAttach {
+		nad, err := nad.GetNADWithName(ctx, helper, netAtt, helper.GetBeforeObject().GetNamespace())
+		if err != nil {
+			if k8s_errors.IsNotFound(err) {
+				// Since the net-attach-def CR should have been manually created by the user and referenced in the spec,
+				// we treat this as a warning because it means that the service will not be able to start.
+				helper.GetLogger().Info(fmt.Sprintf("network-attachment-definition %s not found", netAtt))
+
+				instance.Status.Conditions.Set(condition.FalseCondition(
+					condition.NetworkAttachmentsReadyCondition,
+					condition.ErrorReason,
+					condition.SeverityWarning,
+					condition.NetworkAttachmentsReadyWaitingMessage,
+					netAtt))
+				return serviceAnnotations, ctrl.Result{RequeueAfter: time.Second * 10}, nil
+			}
+			instance.Status.Conditions.Set(condition.FalseCondition(
+				condition.NetworkAttachmentsReadyCondition,
+				condition.ErrorReason,
+				condition.SeverityWarning,
+				condition.NetworkAttachmentsReadyErrorMessage,
+				err.Error()))
+			return serviceAnnotations, ctrl.Result{RequeueAfter: time.Second * 10}, nil
+		}
+
+		if nad != nil {
+			nadList = append(nadList, *nad)
+		}
+	}
+	// Create NetworkAnnotations
+	serviceAnnotations, err = nad.EnsureNetworksAnnotation(nadList)
+	if err != nil {
+		return serviceAnnotations, ctrl.Result{}, fmt.Errorf("failed create network annotation from %s: %w",
+			nAttach, err)
+	}
+	return serviceAnnotations, ctrl.Result{}, err
+}
+
+// ensureDB - ensures the MariaDBAccount, which may be created up front along
+// with its Secret, and the MariaDBDatabase storing the sessions, and waits for
+// the database to be created
+func (r *HorizonReconciler) ensureDB(
+	ctx context.Context,
+	instance *horizonv1beta1.Horizon,
+	h *helper.Helper,
+	envVars *map[string]env.Setter,
+) (*mariadbv1.MariaDBAccount, ctrl.Result, error) {
+	account, _, err := mariadbv1.EnsureMariaDBAccount(
+		ctx, h, instance.Spec.Database.DatabaseAccount,
+		instance.Namespace, false, horizon.DatabaseUsernamePrefix,
+	)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			mariadbv1.MariaDBAccountReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			mariadbv1.MariaDBAccountNotReadyMessage,
+			err.Error()))
+		return nil, ctrl.Result{}, err
+	}
+	instance.Status.Conditions.MarkTrue(
+		mariadbv1.MariaDBAccountReadyCondition,
+		mariadbv1.MariaDBAccountReadyMessage)
+
+	// the pods get the password from the Secret, restart them when it changes
+	_, hash, err := oko_secret.GetSecret(ctx, h, account.Spec.Secret, instance.Namespace)
+	if err != nil {
+		return nil, ctrl.Result{}, err
+	}
+	(*envVars)[account.Spec.Secret] = env.SetValue(hash)
+
+	db := mariadbv1.NewDatabaseForAccount(
+		instance.Spec.Database.DatabaseInstance, // mariadb/galera service to target
+		horizon.GetDatabaseName(instance),       // name used in CREATE DATABASE in mariadb
+		horizon.GetDatabaseCRName(instance),     // CR name for MariaDBDatabase
+		instance.Spec.Database.DatabaseAccount,  // CR name for MariaDBAccount
+		instance.Namespace,                      // namespace
+	)
+
+	// create or patch the DB
+	ctrlResult, err := db.CreateOrPatchAll(ctx, h)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.DBReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.DBReadyErrorMessage,
+			err.Error()))
+		return nil, ctrl.Result{}, err
+	}
+	if (ctrlResult != ctrl.Result{}) {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.DBReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			condition.DBReadyRunningMessage))
+		return nil, ctrlResult, nil
+	}
+
+	// wait for the DB to be setup
+	ctrlResult, err = db.WaitForDBCreated(ctx, h)
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.DBReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.DBReadyErrorMessage,
+			err.Error()))
+		return nil, ctrlResult, err
+	}
+	if (ctrlResult != ctrl.Result{}) {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.DBReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			condition.DBReadyRunningMessage))
+		return nil, ctrlResult, nil
+	}
+
+	// update Status.DatabaseHostname, used to config the service
+	instance.Status.DatabaseHostname = db.GetDatabaseHostname()
+	instance.Status.Conditions.MarkTrue(condition.DBReadyCondition, condition.DBReadyMessage)
+	return account, ctrl.Result{}, nil
+}
+
+// reconcileDBSync - runs the Job creating the session tables, again whenever
+// its inputs change
+func (r *HorizonReconciler) reconcileDBSync(
+	ctx context.Context,
+	instance *horizonv1beta1.Horizon,
+	h *helper.Helper,
+	dbAccount *mariadbv1.MariaDBAccount,
+	serviceLabels map[string]string,
+	inputHash string,
+) (ctrl.Result, error) {
+	Log := r.GetLogger(ctx)
+
+	jobDef := horizon.DbSyncJob(instance, dbAccount, serviceLabels, map[string]string{})
+	// the job is run again when the configuration, and with it the
+	// database, changes
+	jobDef.Spec.Template.Annotations = util.MergeStringMaps(
+		jobDef.Spec.Template.Annotations, map[string]string{"configHash": inputHash})
+	dbSyncjob := job.NewJob(
+		jobDef,
+		horizonv1beta1.DbSyncHash,
+		instance.Spec.PreserveJobs,
+		time.Second*5,
+		instance.Status.Hash[horizonv1beta1.DbSyncHash],
+	)
+	ctrlResult, err := dbSyncjob.DoJob(ctx, h)
+	if (ctrlResult != ctrl.Result{}) {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.DBSyncReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			condition.DBSyncReadyRunningMessage))
+		return ctrlResult, nil
+	}
+	if err != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			condition.DBSyncReadyCondition,
+			condition.ErrorReason,
+			condition.SeverityWarning,
+			condition.DBSyncReadyErrorMessage,
+			err.Error()))
+		return ctrl.Result{}, err
+	}
+	if dbSyncjob.HasChanged() {
+		instance.Status.Hash[horizonv1beta1.DbSyncHash] = dbSyncjob.GetHash()
+		Log.Info(fmt.Sprintf("Service '%s' - Job %s hash added - %s", instance.Name, jobDef.Name, instance.Status.Hash[horizonv1beta1.DbSyncHash]))
+	}
+	instance.Status.Conditions.MarkTrue(condition.DBSyncReadyCondition, condition.DBSyncReadyMessage)
+	return ctrl.Result{}, nil
+}
+
+// getRegionKeystoneAPIName - returns the name of the KeystoneAPI of a region,
+// in the namespace of the Horizon CR unless another one is set
+func getRegionKeystoneAPIName(
+	instance *horizonv1beta1.Horizon,
+	ref *horizonv1beta1.HorizonKeystoneAPIRef,
+) types.NamespacedName {
+	name := types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}
+	if name.Namespace == "" {
+		name.Namespace = instance.Namespace
+	}
+	return name
+}
+
+// getRegions - resolves the Keystone URL of each region rendered in
+// AVAILABLE_REGIONS. authURL is the Keystone used by the dashboard
+func (r *HorizonReconciler) getRegions(
+	ctx context.Context,
+	instance *horizonv1beta1.Horizon,
+	h *helper.Helper,
+	authURL string,
+) ([]horizon.Region, error) {
+	regions := []horizon.Region{}
+	for _, region := range instance.Spec.Regions {
+		keystoneURL := authURL
+		switch {
+		case region.KeystoneURL != "":
+			keystoneURL = region.KeystoneURL
+		case region.KeystoneAPI != nil:
+			keystoneAPI := &keystonev1.KeystoneAPI{}
+			err := h.GetClient().Get(ctx, getRegionKeystoneAPIName(instance, region.KeystoneAPI), keystoneAPI)
+			if err != nil {
+				return nil, fmt.Errorf("region %s: %w", region.Name, err)
+			}
+			keystoneURL, err = keystoneAPI.GetEndpoint(endpoint.Endpoint(instance.Spec.GetKeystoneInterface()))
+			if err != nil {
+				return nil, fmt.Errorf("region %s: %w", region.Name, err)
+			}
+		}
+		regions = append(regions, horizon.Region{
+			Name:        region.Name,
+			KeystoneURL: horizon.GetRegionKeystoneURL(keystoneURL),
+		})
+	}
+	return regions, nil
+}
+
+// reconcileIngress - creates or updates the Route, on OpenShift, or the
+// Ingress exposing the dashboard when spec.ingress is set, deletes them
+// otherwise. It returns the URL of the dashboard, an empty string until the
+// Route or Ingress has a host name
+func (r *HorizonReconciler) reconcileIngress(
+	ctx context.Context,
+	instance *horizonv1beta1.Horizon,
+	h *helper.Helper,
+	serviceLabels map[string]string,
+) (string, error) {
+	Log := r.GetLogger(ctx)
+
+	_, err := h.GetClient().RESTMapper().RESTMapping(horizon.RouteGVK.GroupKind(), horizon.RouteGVK.Version)
+	if err != nil && !meta.IsNoMatchError(err) {
+		return "", err
+	}
+	isOpenShift := err == nil
+
+	if instance.Spec.Ingress == nil {
+		objs := []client.Object{&networkingv1.Ingress{}}
+		if isOpenShift {
+			objs = append(objs, horizon.Route(instance))
+		}
+		for _, obj := range objs {
+			err := h.GetClient().Get(ctx, types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, obj)
+			if k8s_errors.IsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return "", err
+			}
+			if metav1.IsControlledBy(obj, instance) {
+				if err := h.GetClient().Delete(ctx, obj); err != nil && !k8s_errors.IsNotFound(err) {
+					return "", err
+				}
+				Log.Info(fmt.Sprintf("%T %s deleted", obj, obj.GetName()))
+			}
+		}
+		instance.Status.Conditions.Remove(horizonv1beta1.HorizonIngressReadyCondition)
+		return "", nil
+	}
+
+	if isOpenShift {
+		return r.ensureRoute(ctx, instance, h, serviceLabels)
+	}
+	return r.ensureIngress(ctx, instance, h, serviceLabels)
+}
+
+// ensureRoute - creates or updates the Route exposing the dashboard, with the
+// certificate of spec.ingress.tlsSecretName and the CA bundle of the pods
+func (r *HorizonReconciler) ensureRoute(
+	ctx context.Context,
+	instance *horizonv1beta1.Horizon,
+	h *helper.Helper,
+	serviceLabels map[string]string,
+) (string, error) {
+	Log := r.GetLogger(ctx)
+
+	certs := horizon.RouteTLS{}
+	if instance.Spec.Ingress.TLSSecretName != "" {
+		secret, _, err := oko_secret.GetSecret(ctx, h, instance.Spec.Ingress.TLSSecretName, instance.Namespace)
+		if err != nil {
+			return "", err
+		}
+		certs.Certificate = string(secret.Data[tls.CertKey])
+		certs.Key = string(secret.Data[tls.PrivateKey])
+		certs.CACertificate = string(secret.Data[tls.CAKey])
+	}
+	if instance.Spec.TLS.CaBundleSecretName != "" {
+		secret, _, err := oko_secret.GetSecret(ctx, h, instance.Spec.TLS.CaBundleSecretName, instance.Namespace)
+		if err != nil {
+			return "", err
+		}
+		certs.DestinationCACertificate = string(secret.Data[tls.CABundleKey])
+	}
+
+	route := horizon.Route(instance)
+	op, err := controllerutil.CreateOrPatch(ctx, h.GetClient(), route, func() error {
+		horizon.MutateRoute(route, instance, serviceLabels, certs)
+		return controllerutil.SetControllerReference(instance, route, h.GetScheme())
+	})
+	if err != nil {
+		return "", err
+	}
+	if op != controllerutil.OperationResultNone {
+		Log.Info(fmt.Sprintf("Route %s - %s", route.GetName(), op))
+	}
+
+	host := horizon.GetRouteHost(route)
+	if host == "" {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			horizonv1beta1.HorizonIngressReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			horizonv1beta1.HorizonIngressReadyWaitingMessage,
+			"Route", route.GetName()))
+		return "", nil
+	}
+	// every TLS termination mode exposes the dashboard over HTTPS
+	apiEndpoint := "https://" + host
+	instance.Status.Conditions.MarkTrue(
+		horizonv1beta1.HorizonIngressReadyCondition,
+		horizonv1beta1.HorizonIngressReadyMessage,
+		"Route", route.GetName(), apiEndpoint)
+	return apiEndpoint, nil
+}
+
+// ensureIngress - creates or updates the Ingress exposing the dashboard
+func (r *HorizonReconciler) ensureIngress(
+	ctx context.Context,
+	instance *horizonv1beta1.Horizon,
+	h *helper.Helper,
+	serviceLabels map[string]string,
+) (string, error) {
+	Log := r.GetLogger(ctx)
+
+	// the Ingress always terminates TLS, the pods' certificate can't be
+	// served to the clients. The webhook rejects it unless the Route API
+	// was served when the operator started
+	if instance.Spec.GetIngressTLSTermination() == horizonv1beta1.TLSTerminationPassthrough {
+		return "", fmt.Errorf("%w: the cluster doesn't serve %s", ErrIngressPassthrough, horizon.RouteGVK.GroupVersion())
+	}
+
+	ingress := horizon.Ingress(instance)
+	op, err := controllerutil.CreateOrPatch(ctx, h.GetClient(), ingress, func() error {
+		horizon.MutateIngress(ingress, instance, serviceLabels)
+		return controllerutil.SetControllerReference(instance, ingress, h.GetScheme())
+	})
+	if err != nil {
+		return "", err
+	}
+	if op != controllerutil.OperationResultNone {
+		Log.Info(fmt.Sprintf("Ingress %s - %s", ingress.Name, op))
+	}
+
+	apiEndpoint := horizon.GetIngressEndpoint(ingress)
+	if apiEndpoint == "" {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			horizonv1beta1.HorizonIngressReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			horizonv1beta1.HorizonIngressReadyWaitingMessage,
+			"Ingress", ingress.Name))
+		return "", nil
+	}
+	instance.Status.Conditions.MarkTrue(
+		horizonv1beta1.HorizonIngressReadyCondition,
+		horizonv1beta1.HorizonIngressReadyMessage,
+		"Ingress", ingress.Name, apiEndpoint)
+	return apiEndpoint, nil
+}
+
+// reconcileHTTPRoute - creates or updates the HTTPRoute exposing the
+// dashboard through the Gateway of spec.override.gateway, deletes it
+// otherwise. It returns the URL of the dashboard, an empty string until the
+// HTTPRoute is Accepted with ResolvedRefs and has a host name
+func (r *HorizonReconciler) reconcileHTTPRoute(
+	ctx context.Context,
+	instance *horizonv1beta1.Horizon,
+	h *helper.Helper,
+	serviceLabels map[string]string,
+) (string, error) {
+	Log := r.GetLogger(ctx)
+
+	_, err := h.GetClient().RESTMapper().RESTMapping(horizon.HTTPRouteGVK.GroupKind(), horizon.HTTPRouteGVK.Version)
+	if err != nil && !meta.IsNoMatchError(err) {
+		return "", err
+	}
+	isServed := err == nil
+
+	if instance.Spec.Override.Gateway == nil {
+		if isServed {
+			route := horizon.HTTPRoute(instance)
+			err := h.GetClient().Get(ctx, types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, route)
+			if err != nil && !k8s_errors.IsNotFound(err) {
+				return "", err
+			}
+			if err == nil && metav1.IsControlledBy(route, instance) {
+				if err := h.GetClient().Delete(ctx, route); err != nil && !k8s_errors.IsNotFound(err) {
+					return "", err
+				}
+				Log.Info(fmt.Sprintf("HTTPRoute %s deleted", route.GetName()))
+			}
+		}
+		instance.Status.Conditions.Remove(horizonv1beta1.HorizonHTTPRouteReadyCondition)
+		return "", nil
+	}
+	if !isServed {
+		return "", fmt.Errorf("%w: %s", ErrGatewayAPINotServed, horizon.HTTPRouteGVK.GroupVersion())
+	}
+
+	// the backend is the port of the rendered Service, the one the Route
+	// targets by name
+	svc := &corev1.Service{}
+	if err := h.GetClient().Get(ctx, types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, svc); err != nil {
+		return "", err
+	}
+	port := horizon.GetServicePort(svc)
+	if port == 0 {
+		return "", fmt.Errorf("%w: service %s has no %s port", ErrHTTPRouteBackend, svc.Name, horizon.ServiceName)
+	}
+
+	route := horizon.HTTPRoute(instance)
+	op, err := controllerutil.CreateOrPatch(ctx, h.GetClient(), route, func() error {
+		horizon.MutateHTTPRoute(route, instance, serviceLabels, port)
+		return controllerutil.SetControllerReference(instance, route, h.GetScheme())
+	})
+	if err != nil {
+		return "", err
+	}
+	if op != controllerutil.OperationResultNone {
+		Log.Info(fmt.Sprintf("HTTPRoute %s - %s", route.GetName(), op))
+	}
+
+	gateway := horizon.Gateway(instance)
+	if cond := horizon.GetHTTPRouteNotReadyCondition(route, instance); cond != nil {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			horizonv1beta1.HorizonHTTPRouteReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			horizonv1beta1.HorizonHTTPRouteReadyWaitingMessage,
+			cond.Type, gateway.GetName(), cond.Status, cond.Message))
+		return "", nil
+	}
+
+	err = h.GetClient().Get(ctx, types.NamespacedName{Name: gateway.GetName(), Namespace: gateway.GetNamespace()}, gateway)
+	if err != nil && !k8s_errors.IsNotFound(err) {
+		return "", err
+	}
+	apiEndpoint := horizon.GetHTTPRouteEndpoint(instance, gateway)
+	if apiEndpoint == "" {
+		instance.Status.Conditions.Set(condition.FalseCondition(
+			horizonv1beta1.HorizonHTTPRouteReadyCondition,
+			condition.RequestedReason,
+			condition.SeverityInfo,
+			horizonv1beta1.HorizonHTTPRouteReadyAddressMessage,
+			gateway.GetName()))
+		return "", nil
+	}
+	instance.Status.Conditions.MarkTrue(
+		horizonv1beta1.HorizonHTTPRouteReadyCondition,
+		horizonv1beta1.HorizonHTTPRouteReadyMessage,
+		gateway.GetName())
+	return apiEndpoint, nil
 }
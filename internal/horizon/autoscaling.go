@@ -0,0 +1,83 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package horizon
+
+import (
+	horizonv1 "github.com/openstack-k8s-operators/horizon-operator/api/v1beta1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+// DefaultTargetCPUUtilizationPercentage - CPU target used when the autoscaling
+// section doesn't define any target
+const DefaultTargetCPUUtilizationPercentage int32 = 80
+
+// HorizontalPodAutoscaler - returns the HorizontalPodAutoscaler scaling the
+// horizon Deployment
+func HorizontalPodAutoscaler(
+	instance *horizonv1.Horizon,
+	labels map[string]string,
+) *autoscalingv2.HorizontalPodAutoscaler {
+	as := instance.Spec.Autoscaling
+
+	targetCPU := as.TargetCPUUtilizationPercentage
+	if targetCPU == nil && as.TargetMemoryUtilizationPercentage == nil {
+		targetCPU = ptr.To(DefaultTargetCPUUtilizationPercentage)
+	}
+
+	metrics := []autoscalingv2.MetricSpec{}
+	if targetCPU != nil {
+		metrics = append(metrics, resourceMetric(corev1.ResourceCPU, *targetCPU))
+	}
+	if as.TargetMemoryUtilizationPercentage != nil {
+		metrics = append(metrics, resourceMetric(corev1.ResourceMemory, *as.TargetMemoryUtilizationPercentage))
+	}
+
+	return &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      instance.Name,
+			Namespace: instance.Namespace,
+			Labels:    labels,
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       instance.Name,
+			},
+			MinReplicas: as.MinReplicas,
+			MaxReplicas: as.MaxReplicas,
+			Metrics:     metrics,
+			Behavior:    as.Behavior,
+		},
+	}
+}
+
+// resourceMetric - average utilization target of a pod resource
+func resourceMetric(name corev1.ResourceName, utilization int32) autoscalingv2.MetricSpec {
+	return autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricSource{
+			Name: name,
+			Target: autoscalingv2.MetricTarget{
+				Type:               autoscalingv2.UtilizationMetricType,
+				AverageUtilization: ptr.To(utilization),
+			},
+		},
+	}
+}
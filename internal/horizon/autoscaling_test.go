@@ -0,0 +1,76 @@
+package horizon
+
+import (
+	"testing"
+
+	horizonv1 "github.com/openstack-k8s-operators/horizon-operator/api/v1beta1"
+	"github.com/stretchr/testify/assert"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestHorizontalPodAutoscaler(t *testing.T) {
+
+	testCases := []struct {
+		name            string
+		autoscaling     *horizonv1.HorizonAutoscalingSpec
+		expectedMetrics []autoscalingv2.MetricSpec
+	}{
+		{
+			name: "Default CPU target",
+			autoscaling: &horizonv1.HorizonAutoscalingSpec{
+				MinReplicas: ptr.To[int32](2),
+				MaxReplicas: 5,
+			},
+			expectedMetrics: []autoscalingv2.MetricSpec{
+				resourceMetric(corev1.ResourceCPU, 80),
+			},
+		},
+		{
+			name: "Memory target only",
+			autoscaling: &horizonv1.HorizonAutoscalingSpec{
+				MinReplicas:                       ptr.To[int32](2),
+				MaxReplicas:                       5,
+				TargetMemoryUtilizationPercentage: ptr.To[int32](70),
+			},
+			expectedMetrics: []autoscalingv2.MetricSpec{
+				resourceMetric(corev1.ResourceMemory, 70),
+			},
+		},
+		{
+			name: "CPU and memory targets",
+			autoscaling: &horizonv1.HorizonAutoscalingSpec{
+				MinReplicas:                       ptr.To[int32](2),
+				MaxReplicas:                       5,
+				TargetCPUUtilizationPercentage:    ptr.To[int32](60),
+				TargetMemoryUtilizationPercentage: ptr.To[int32](70),
+			},
+			expectedMetrics: []autoscalingv2.MetricSpec{
+				resourceMetric(corev1.ResourceCPU, 60),
+				resourceMetric(corev1.ResourceMemory, 70),
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := &horizonv1.Horizon{
+				ObjectMeta: metav1.ObjectMeta{Name: "dashboard"},
+				Spec: horizonv1.HorizonSpec{
+					HorizonSpecCore: horizonv1.HorizonSpecCore{
+						Autoscaling: tt.autoscaling,
+					},
+				},
+			}
+			hpa := HorizontalPodAutoscaler(instance, map[string]string{})
+			assert.Equal(t, "Deployment", hpa.Spec.ScaleTargetRef.Kind)
+			assert.Equal(t, "dashboard", hpa.Name)
+			assert.Equal(t, "dashboard", hpa.Spec.ScaleTargetRef.Name)
+			assert.Equal(t, tt.autoscaling.MinReplicas, hpa.Spec.MinReplicas)
+			assert.Equal(t, tt.autoscaling.MaxReplicas, hpa.Spec.MaxReplicas)
+			assert.Equal(t, tt.expectedMetrics, hpa.Spec.Metrics)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package horizon
+
+import (
+	"path"
+	"slices"
+	"strings"
+
+	horizonv1 "github.com/openstack-k8s-operators/horizon-operator/api/v1beta1"
+)
+
+const (
+	// PolicyFilesPath - directory where horizon looks for the service
+	// policy files (POLICY_FILES_PATH)
+	PolicyFilesPath = "/etc/openstack-dashboard"
+
+	// LocalSettingsPath - directory of the local_settings snippets, loaded in
+	// alphabetical order after local_settings.py
+	LocalSettingsPath = "/etc/openstack-dashboard/local_settings.d"
+
+	// HttpdCustomConfPath - directory of the httpd snippets included by the
+	// horizon VirtualHost
+	HttpdCustomConfPath = "/etc/httpd/conf_custom"
+)
+
+// configOverwriteDirs - directory where each type of DefaultConfigOverwrite
+// file is placed
+var configOverwriteDirs = map[string]string{
+	horizonv1.ConfigOverwritePolicy:   PolicyFilesPath,
+	horizonv1.ConfigOverwriteSettings: LocalSettingsPath,
+	horizonv1.ConfigOverwriteHttpd:    HttpdCustomConfPath,
+}
+
+// ConfigOverwriteFile - a DefaultConfigOverwrite key and the path where kolla
+// places it in the horizon container
+type ConfigOverwriteFile struct {
+	Source string
+	Dest   string
+}
+
+// GetConfigOverwriteFiles - returns the destination of each DefaultConfigOverwrite
+// key, sorted by name, along with the keys that don't match any destination
+// rule (see horizonv1.GetConfigOverwriteType):
+//   - <service>_policy.{json,yaml,yml} files go to PolicyFilesPath
+//   - *.py files go to LocalSettingsPath
+//   - *.conf files go to HttpdCustomConfPath
+//
+// The webhook rejects the unknown keys, they are only reported when it is
+// bypassed
+func GetConfigOverwriteFiles(overwrite map[string]string) ([]ConfigOverwriteFile, []string) {
+	files := []ConfigOverwriteFile{}
+	unknown := []string{}
+
+	for name := range overwrite {
+		dir, ok := configOverwriteDirs[horizonv1.GetConfigOverwriteType(name)]
+		if !ok {
+			unknown = append(unknown, name)
+			continue
+		}
+		files = append(files, ConfigOverwriteFile{
+			Source: name,
+			Dest:   path.Join(dir, name),
+		})
+	}
+
+	slices.SortFunc(files, func(a, b ConfigOverwriteFile) int {
+		return strings.Compare(a.Source, b.Source)
+	})
+	slices.Sort(unknown)
+	return files, unknown
+}
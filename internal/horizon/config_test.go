@@ -0,0 +1,61 @@
+package horizon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetConfigOverwriteFiles(t *testing.T) {
+
+	testCases := []struct {
+		name            string
+		overwrite       map[string]string
+		expectedFiles   []ConfigOverwriteFile
+		expectedUnknown []string
+	}{
+		{
+			name:            "No overwrite",
+			overwrite:       nil,
+			expectedFiles:   []ConfigOverwriteFile{},
+			expectedUnknown: []string{},
+		},
+		{
+			name: "Known destinations",
+			overwrite: map[string]string{
+				"nova_policy.yaml":     "",
+				"keystone_policy.json": "",
+				"_50_plugin.py":        "",
+				"10-headers.conf":      "",
+			},
+			expectedFiles: []ConfigOverwriteFile{
+				{Source: "10-headers.conf", Dest: "/etc/httpd/conf_custom/10-headers.conf"},
+				{Source: "_50_plugin.py", Dest: "/etc/openstack-dashboard/local_settings.d/_50_plugin.py"},
+				{Source: "keystone_policy.json", Dest: "/etc/openstack-dashboard/keystone_policy.json"},
+				{Source: "nova_policy.yaml", Dest: "/etc/openstack-dashboard/nova_policy.yaml"},
+			},
+			expectedUnknown: []string{},
+		},
+		{
+			name: "Unknown keys",
+			overwrite: map[string]string{
+				"logging.conf.j2":   "",
+				"policy.yaml":       "",
+				"cinder_policy.yml": "",
+				"README":            "",
+			},
+			expectedFiles: []ConfigOverwriteFile{
+				{Source: "cinder_policy.yml", Dest: "/etc/openstack-dashboard/cinder_policy.yml"},
+			},
+			expectedUnknown: []string{"README", "logging.conf.j2", "policy.yaml"},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			files, unknown := GetConfigOverwriteFiles(tt.overwrite)
+			assert.Equal(t, tt.expectedFiles, files)
+			assert.Equal(t, tt.expectedUnknown, unknown)
+		})
+	}
+}
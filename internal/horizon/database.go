@@ -0,0 +1,39 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package horizon
+
+import (
+	"strings"
+
+	horizonv1 "github.com/openstack-k8s-operators/horizon-operator/api/v1beta1"
+)
+
+const (
+	// DatabaseUsernamePrefix - prefix of the MariaDB user generated for the
+	// MariaDBAccount when it doesn't exist yet
+	DatabaseUsernamePrefix = "horizon"
+)
+
+// GetDatabaseName - returns the name of the MariaDB database of the instance,
+// which can't contain dashes
+func GetDatabaseName(instance *horizonv1.Horizon) string {
+	return strings.ReplaceAll(instance.Name, "-", "_")
+}
+
+// GetDatabaseCRName - returns the name of the MariaDBDatabase of the instance
+func GetDatabaseCRName(instance *horizonv1.Horizon) string {
+	return instance.Name
+}
@@ -0,0 +1,74 @@
+package horizon
+
+import (
+	"testing"
+
+	horizonv1 "github.com/openstack-k8s-operators/horizon-operator/api/v1beta1"
+	mariadbv1 "github.com/openstack-k8s-operators/mariadb-operator/api/v1beta1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newDatabaseInstance() *horizonv1.Horizon {
+	return &horizonv1.Horizon{
+		ObjectMeta: metav1.ObjectMeta{Name: "horizon-dashboard", Namespace: "openstack"},
+		Spec: horizonv1.HorizonSpec{
+			HorizonSpecCore: horizonv1.HorizonSpecCore{
+				SessionBackend: horizonv1.SessionBackendDatabase,
+				Database: &horizonv1.HorizonDatabaseSpec{
+					DatabaseInstance: "openstack",
+					DatabaseAccount:  "horizon-sessions",
+				},
+			},
+		},
+	}
+}
+
+func TestGetDatabaseName(t *testing.T) {
+	instance := newDatabaseInstance()
+
+	assert.Equal(t, "horizon_dashboard", GetDatabaseName(instance))
+	assert.Equal(t, "horizon-dashboard", GetDatabaseCRName(instance))
+}
+
+func TestDbSyncJob(t *testing.T) {
+	instance := newDatabaseInstance()
+	instance.Spec.ContainerImage = "horizon:latest"
+
+	account := &mariadbv1.MariaDBAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: "horizon-sessions", Namespace: "openstack"},
+		Spec: mariadbv1.MariaDBAccountSpec{
+			UserName: "horizon_3a2d",
+			Secret:   "horizon-sessions-db-secret",
+		},
+	}
+
+	job := DbSyncJob(instance, account, map[string]string{"service": ServiceName}, map[string]string{})
+
+	assert.Equal(t, "horizon-dashboard-db-sync", job.Name)
+	assert.Equal(t, corev1.RestartPolicyOnFailure, job.Spec.Template.Spec.RestartPolicy)
+	container := job.Spec.Template.Spec.Containers[0]
+	assert.Equal(t, "horizon:latest", container.Image)
+
+	var password *corev1.EnvVar
+	for i := range container.Env {
+		if container.Env[i].Name == "DB_PASSWORD" {
+			password = &container.Env[i]
+		}
+	}
+	if assert.NotNil(t, password) {
+		assert.Equal(t, "horizon-sessions-db-secret", password.ValueFrom.SecretKeyRef.Name)
+		assert.Equal(t, mariadbv1.DatabasePasswordSelector, password.ValueFrom.SecretKeyRef.Key)
+	}
+
+	var kollaConfig *corev1.VolumeMount
+	for i := range container.VolumeMounts {
+		if container.VolumeMounts[i].MountPath == "/var/lib/kolla/config_files/config.json" {
+			kollaConfig = &container.VolumeMounts[i]
+		}
+	}
+	if assert.NotNil(t, kollaConfig) {
+		assert.Equal(t, "horizon-dbsync.json", kollaConfig.SubPath)
+	}
+}
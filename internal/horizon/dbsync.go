@@ -0,0 +1,93 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package horizon
+
+import (
+	horizonv1 "github.com/openstack-k8s-operators/horizon-operator/api/v1beta1"
+	env "github.com/openstack-k8s-operators/lib-common/modules/common/env"
+	mariadbv1 "github.com/openstack-k8s-operators/mariadb-operator/api/v1beta1"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// DBSyncCommand - creates the database tables of the sessions, the
+	// manage.py command is set in horizon-dbsync.json
+	DBSyncCommand = "/usr/local/bin/kolla_start"
+)
+
+// DbSyncJob - returns the Job running the database migrations
+func DbSyncJob(
+	instance *horizonv1.Horizon,
+	dbAccount *mariadbv1.MariaDBAccount,
+	labels map[string]string,
+	annotations map[string]string,
+) *batchv1.Job {
+	envVars := map[string]env.Setter{}
+	envVars["KOLLA_CONFIG_STRATEGY"] = env.SetValue("COPY_ALWAYS")
+	envVars["KOLLA_BOOTSTRAP"] = env.SetValue("true")
+	envVars["DB_PASSWORD"] = setValueFromSecret(
+		dbAccount.Spec.Secret, mariadbv1.DatabasePasswordSelector)
+
+	volumes := getVolumes(instance.Name, GetExtraMounts(instance), HorizonPropagation)
+	volumeMounts := getDBSyncVolumeMounts(GetExtraMounts(instance), HorizonPropagation)
+
+	// the database client verifies the certificate of Galera with the CA
+	if instance.Spec.TLS.CaBundleSecretName != "" {
+		volumes = append(volumes, instance.Spec.TLS.CreateVolume())
+		volumeMounts = append(volumeMounts, instance.Spec.TLS.CreateVolumeMounts(nil)...)
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      instance.Name + "-db-sync",
+			Namespace: instance.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: annotations,
+				},
+				Spec: corev1.PodSpec{
+					RestartPolicy:      corev1.RestartPolicyOnFailure,
+					ServiceAccountName: instance.RbacResourceName(),
+					Containers: []corev1.Container{
+						{
+							Name: ServiceName + "-db-sync",
+							Command: []string{
+								"/bin/bash",
+							},
+							Args:            []string{"-c", DBSyncCommand},
+							Image:           instance.Spec.ContainerImage,
+							SecurityContext: HttpdSecurityContext(),
+							Env:             env.MergeEnvs([]corev1.EnvVar{}, envVars),
+							VolumeMounts:    volumeMounts,
+						},
+					},
+					Volumes: volumes,
+				},
+			},
+		},
+	}
+	if instance.Spec.NodeSelector != nil {
+		job.Spec.Template.Spec.NodeSelector = *instance.Spec.NodeSelector
+	}
+
+	return job
+}
@@ -16,6 +16,9 @@ limitations under the License.
 package horizon
 
 import (
+	"maps"
+	"strings"
+
 	horizonv1 "github.com/openstack-k8s-operators/horizon-operator/api/v1beta1"
 	memcachedv1 "github.com/openstack-k8s-operators/infra-operator/apis/memcached/v1beta1"
 	topologyv1 "github.com/openstack-k8s-operators/infra-operator/apis/topology/v1beta1"
@@ -23,6 +26,7 @@ import (
 	"github.com/openstack-k8s-operators/lib-common/modules/common/affinity"
 	env "github.com/openstack-k8s-operators/lib-common/modules/common/env"
 	"github.com/openstack-k8s-operators/lib-common/modules/common/tls"
+	mariadbv1 "github.com/openstack-k8s-operators/mariadb-operator/api/v1beta1"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -33,7 +37,6 @@ import (
 const (
 	// ServiceCommand is the command used to run Kolla and launch the initial Apache process
 	ServiceCommand           = "/usr/local/bin/kolla_theme_setup && /usr/local/bin/kolla_start"
-	horizonDashboardURL      = "/dashboard/auth/login/?next=/dashboard/"
 	horizonContainerPortName = "horizon"
 )
 
@@ -56,9 +59,13 @@ func Deployment(
 	enabledServices map[string]string,
 	topology *topologyv1.Topology,
 	memcached *memcachedv1.Memcached,
+	dbAccount *mariadbv1.MariaDBAccount,
 ) (*appsv1.Deployment, error) {
 
 	args := []string{"-c", ServiceCommand}
+	if len(GetRegionCABundleSecretNames(instance.Spec.Regions)) > 0 {
+		args = []string{"-c", RegionCAServiceCommand}
+	}
 
 	containerPort := corev1.ContainerPort{
 		Name:          horizonContainerPortName,
@@ -66,15 +73,29 @@ func Deployment(
 		ContainerPort: HorizonPort,
 	}
 
-	livenessProbe := formatProbes()
-	readinessProbe := formatProbes()
-	startupProbe := formatStartupProbe()
+	probePath := GetProbePath(instance.Spec.GetWebRoot())
+	livenessProbe := formatProbes(probePath)
+	readinessProbe := formatProbes(probePath)
+	startupProbe := formatStartupProbe(probePath)
 
 	envVars := getEnvVars(configHash, enabledServices)
+	maps.Copy(envVars, getSSOEnvVars(instance.Spec.SSO))
+	// dbAccount is nil with the other session backends
+	if dbAccount != nil {
+		envVars["DB_PASSWORD"] = setValueFromSecret(
+			dbAccount.Spec.Secret, mariadbv1.DatabasePasswordSelector)
+	}
 
 	// create Volumes and VolumeMounts
-	volumes := append(getVolumes(instance.Name, instance.Spec.ExtraMounts, HorizonPropagation), GetLogVolume())
-	volumeMounts := append(getVolumeMounts(instance.Spec.ExtraMounts, HorizonPropagation), GetLogVolumeMount())
+	extraMounts := GetExtraMounts(instance)
+	volumes := append(getVolumes(instance.Name, extraMounts, HorizonPropagation), GetLogVolume())
+	volumeMounts := append(getVolumeMounts(extraMounts, HorizonPropagation), GetLogVolumeMount())
+
+	// add CA cert if defined, it is also used by the database client
+	if instance.Spec.TLS.CaBundleSecretName != "" {
+		volumes = append(volumes, instance.Spec.TLS.CreateVolume())
+		volumeMounts = append(volumeMounts, instance.Spec.TLS.CreateVolumeMounts(nil)...)
+	}
 
 	if instance.Spec.TLS.Enabled() {
 		tlsRequiredOptions := TLSRequiredOptions{
@@ -97,15 +118,24 @@ func Deployment(
 		containerPort = *tlsRequiredOptions.containerPort
 	}
 
-	// add MTLS cert if defined
-	if memcached.GetMemcachedMTLSSecret() != "" {
+	// add MTLS cert if defined, memcached is nil with the other session
+	// backends
+	if memcached != nil && memcached.GetMemcachedMTLSSecret() != "" {
 		volumes = append(volumes, memcached.CreateMTLSVolume())
 		volumeMounts = append(volumeMounts, memcached.CreateMTLSVolumeMounts(nil, nil)...)
 	}
 
+	// add the CA bundles of the regions, concatenated by region_ca_setup
+	volumes = append(volumes, getRegionCAVolumes(instance.Spec.Regions)...)
+	volumeMounts = append(volumeMounts, getRegionCAVolumeMounts(instance.Spec.Regions)...)
+
+	// add the service policy files, copied by kolla to PolicyFilesPath
+	volumes = append(volumes, getPolicyVolumes(instance.Spec.Policies)...)
+	volumeMounts = append(volumeMounts, getPolicyVolumeMounts(instance.Spec.Policies)...)
+
 	deployment := &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      ServiceName,
+			Name:      instance.Name,
 			Namespace: instance.Namespace,
 		},
 		Spec: appsv1.DeploymentSpec{
@@ -175,6 +205,20 @@ func Deployment(
 		)
 	}
 
+	if rs := instance.Spec.RolloutStrategy; rs != nil {
+		deployment.Spec.Strategy = appsv1.DeploymentStrategy{
+			Type: appsv1.RollingUpdateDeploymentStrategyType,
+			RollingUpdate: &appsv1.RollingUpdateDeployment{
+				MaxSurge:       rs.MaxSurge,
+				MaxUnavailable: rs.MaxUnavailable,
+			},
+		}
+		if rs.MinReadySeconds != nil {
+			deployment.Spec.MinReadySeconds = *rs.MinReadySeconds
+		}
+		deployment.Spec.ProgressDeadlineSeconds = rs.ProgressDeadlineSeconds
+	}
+
 	return deployment, nil
 }
 
@@ -183,20 +227,71 @@ func getEnvVars(configHash string, enabledServices map[string]string) map[string
 	envVars := map[string]env.Setter{}
 
 	envVars["KOLLA_CONFIG_STRATEGY"] = env.SetValue("COPY_ALWAYS")
-	envVars["ENABLE_DESIGNATE"] = env.SetValue("yes")
-	envVars["ENABLE_HEAT"] = env.SetValue("yes")
-	envVars["ENABLE_IRONIC"] = env.SetValue("yes")
-	envVars["ENABLE_MANILA"] = env.SetValue("yes")
-	envVars["ENABLE_OCTAVIA"] = env.SetValue("yes")
-	envVars["ENABLE_CLOUDKITTY"] = env.SetValue(enabledServices["cloudkitty"])
-	envVars["ENABLE_WATCHER"] = env.SetValue(enabledServices["watcher"])
+	for plugin, enabled := range enabledServices {
+		envVars["ENABLE_"+strings.ToUpper(plugin)] = env.SetValue(enabled)
+	}
 	envVars["CONFIG_HASH"] = env.SetValue(configHash)
 	envVars["UNPACK_THEME"] = env.SetValue("true")
+	// the addresses of the pod added to ALLOWED_HOSTS, status.podIPs lists
+	// both families on a dual-stack cluster
+	envVars["POD_IP"] = setValueFromField("status.podIP")
+	envVars["POD_IPS"] = setValueFromField("status.podIPs")
 
 	return envVars
 }
 
-func formatProbes() *corev1.Probe {
+// getSSOEnvVars - exposes the OpenID Connect client credentials to httpd,
+// which resolves them in the mod_auth_openidc directives of httpd.conf
+func getSSOEnvVars(sso *horizonv1.HorizonSSOSpec) map[string]env.Setter {
+
+	envVars := map[string]env.Setter{}
+	if sso == nil {
+		return envVars
+	}
+
+	idp := sso.GetOIDCProvider()
+	if idp == nil {
+		return envVars
+	}
+	envVars["OIDC_CLIENT_SECRET"] = setValueFromSecret(idp.CredentialsSecret, horizonv1.SSOClientSecretSelector)
+	envVars["OIDC_CRYPTO_PASSPHRASE"] = setValueFromSecret(idp.CredentialsSecret, horizonv1.SSOCryptoPassphraseSelector)
+
+	return envVars
+}
+
+// setValueFromSecret - returns an env.Setter referencing the key of a Secret
+func setValueFromSecret(secretName string, key string) env.Setter {
+	return func(envVar *corev1.EnvVar) {
+		envVar.ValueFrom = &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: secretName,
+				},
+				Key: key,
+			},
+		}
+	}
+}
+
+// setValueFromField - returns an env.Setter exposing a field of the pod
+// through the downward API
+func setValueFromField(fieldPath string) env.Setter {
+	return func(envVar *corev1.EnvVar) {
+		envVar.ValueFrom = &corev1.EnvVarSource{
+			FieldRef: &corev1.ObjectFieldSelector{
+				FieldPath: fieldPath,
+			},
+		}
+	}
+}
+
+// GetProbePath - returns the path of the login page probed in the horizon
+// container for the given web root
+func GetProbePath(webRoot string) string {
+	return webRoot + "/auth/login/?next=" + webRoot + "/"
+}
+
+func formatProbes(path string) *corev1.Probe {
 
 	return &corev1.Probe{
 		TimeoutSeconds:      5,
@@ -204,14 +299,14 @@ func formatProbes() *corev1.Probe {
 		InitialDelaySeconds: 10,
 		ProbeHandler: corev1.ProbeHandler{
 			HTTPGet: &corev1.HTTPGetAction{
-				Path: horizonDashboardURL,
+				Path: path,
 				Port: intstr.FromString(horizonContainerPortName),
 			},
 		},
 	}
 }
 
-func formatStartupProbe() *corev1.Probe {
+func formatStartupProbe(path string) *corev1.Probe {
 
 	return &corev1.Probe{
 		TimeoutSeconds:   5,
@@ -219,7 +314,7 @@ func formatStartupProbe() *corev1.Probe {
 		FailureThreshold: 12,
 		ProbeHandler: corev1.ProbeHandler{
 			HTTPGet: &corev1.HTTPGetAction{
-				Path: horizonDashboardURL,
+				Path: path,
 				Port: intstr.FromString(horizonContainerPortName),
 			},
 		},
@@ -236,12 +331,6 @@ func (t *TLSRequiredOptions) formatTLSOptions(instance *horizonv1.Horizon) error
 		return err
 	}
 
-	// add CA cert if defined
-	if instance.Spec.TLS.CaBundleSecretName != "" {
-		t.volumes = append(t.volumes, instance.Spec.TLS.CreateVolume())
-		t.volumeMounts = append(t.volumeMounts, instance.Spec.TLS.CreateVolumeMounts(nil)...)
-	}
-
 	t.containerPort.ContainerPort = HorizonPortTLS
 	t.livenessProbe.HTTPGet.Scheme = corev1.URISchemeHTTPS
 	t.readinessProbe.HTTPGet.Scheme = corev1.URISchemeHTTPS
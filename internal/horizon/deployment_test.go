@@ -140,3 +140,20 @@ func TestFormatTLSOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestGetProbePath(t *testing.T) {
+	assert.Equal(t, "/dashboard/auth/login/?next=/dashboard/", GetProbePath("/dashboard"))
+	assert.Equal(t, "/auth/login/?next=/", GetProbePath(""))
+}
+
+func TestGetEnvVarsPodIPs(t *testing.T) {
+	envVars := getEnvVars("hash", map[string]string{})
+	for name, fieldPath := range map[string]string{
+		"POD_IP":  "status.podIP",
+		"POD_IPS": "status.podIPs",
+	} {
+		envVar := corev1.EnvVar{Name: name}
+		envVars[name](&envVar)
+		assert.Equal(t, fieldPath, envVar.ValueFrom.FieldRef.FieldPath)
+	}
+}
@@ -0,0 +1,80 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package horizon
+
+import (
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+
+	horizonv1 "github.com/openstack-k8s-operators/horizon-operator/api/v1beta1"
+)
+
+// GetHostnames - returns the host names accepted by the dashboard, the host
+// of the endpoint followed by the additional host names, without duplicates.
+// An IPv6 endpoint is kept between brackets, as Django matches ALLOWED_HOSTS
+// against the host of the Host header
+func GetHostnames(endpoint *url.URL, additionalHostnames []string) []string {
+	hostnames := []string{}
+	if host := endpoint.Hostname(); host != "" {
+		if ip := net.ParseIP(host); ip != nil && ip.To4() == nil {
+			host = "[" + host + "]"
+		}
+		hostnames = append(hostnames, host)
+	}
+	for _, hostname := range additionalHostnames {
+		if !slices.Contains(hostnames, hostname) {
+			hostnames = append(hostnames, hostname)
+		}
+	}
+	return hostnames
+}
+
+// GetCSRFTrustedOrigins - returns the CSRF_TRUSTED_ORIGINS of the dashboard,
+// the origin of the endpoint followed by the additional host names reached
+// with the scheme of the endpoint. Nothing is returned until the endpoint
+// has a scheme, Django rejects origins without one
+func GetCSRFTrustedOrigins(endpoint *url.URL, additionalHostnames []string) []string {
+	origins := []string{}
+	if endpoint.Scheme == "" {
+		return origins
+	}
+	if endpoint.Host != "" {
+		origins = append(origins, endpoint.Scheme+"://"+endpoint.Host)
+	}
+	for _, hostname := range additionalHostnames {
+		if hostname == "" {
+			continue
+		}
+		origin := endpoint.Scheme + "://" + hostname
+		if !slices.Contains(origins, origin) {
+			origins = append(origins, origin)
+		}
+	}
+	return origins
+}
+
+// GetAdditionalHostnamesAnnotation - returns the annotation listing the
+// additional host names, empty when there are none
+func GetAdditionalHostnamesAnnotation(instance *horizonv1.Horizon) map[string]string {
+	if len(instance.Spec.AdditionalHostnames) == 0 {
+		return map[string]string{}
+	}
+	return map[string]string{
+		horizonv1.AdditionalHostnamesAnnotation: strings.Join(instance.Spec.AdditionalHostnames, ","),
+	}
+}
@@ -0,0 +1,48 @@
+package horizon
+
+import (
+	"net/url"
+	"testing"
+
+	horizonv1 "github.com/openstack-k8s-operators/horizon-operator/api/v1beta1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetHostnames(t *testing.T) {
+	endpoint, err := url.Parse("https://horizon.apps.example.com:8443")
+	assert.NoError(t, err)
+	additional := []string{"dashboard.example.com", "horizon.apps.example.com"}
+
+	assert.Equal(t,
+		[]string{"horizon.apps.example.com", "dashboard.example.com"},
+		GetHostnames(endpoint, additional))
+	assert.Equal(t,
+		[]string{"https://horizon.apps.example.com:8443", "https://dashboard.example.com", "https://horizon.apps.example.com"},
+		GetCSRFTrustedOrigins(endpoint, additional))
+
+	assert.Equal(t, []string{"dashboard.example.com"}, GetHostnames(&url.URL{}, additional[:1]))
+
+	// origins without a scheme or a host are skipped
+	assert.Empty(t, GetCSRFTrustedOrigins(&url.URL{}, additional))
+	assert.Equal(t,
+		[]string{"https://dashboard.example.com"},
+		GetCSRFTrustedOrigins(&url.URL{Scheme: "https"}, []string{"", "dashboard.example.com"}))
+
+	// Django keeps the brackets of an IPv6 Host header
+	endpoint, err = url.Parse("http://[fd00::10]:8080")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"[fd00::10]"}, GetHostnames(endpoint, nil))
+	endpoint, err = url.Parse("http://192.0.2.10")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"192.0.2.10"}, GetHostnames(endpoint, nil))
+}
+
+func TestGetAdditionalHostnamesAnnotation(t *testing.T) {
+	instance := &horizonv1.Horizon{}
+	assert.Empty(t, GetAdditionalHostnamesAnnotation(instance))
+
+	instance.Spec.AdditionalHostnames = []string{"dashboard.example.com", "cloud.example.org"}
+	assert.Equal(t,
+		map[string]string{horizonv1.AdditionalHostnamesAnnotation: "dashboard.example.com,cloud.example.org"},
+		GetAdditionalHostnamesAnnotation(instance))
+}
@@ -0,0 +1,276 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package horizon
+
+import (
+	"slices"
+	"strings"
+
+	horizonv1 "github.com/openstack-k8s-operators/horizon-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// The Gateway API objects are handled as unstructured objects, the
+// gateway.networking.k8s.io API is only served on the clusters where it is
+// installed
+var (
+	// HTTPRouteGVK -
+	HTTPRouteGVK = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "HTTPRoute"}
+	// GatewayGVK -
+	GatewayGVK = schema.GroupVersionKind{Group: "gateway.networking.k8s.io", Version: "v1", Kind: "Gateway"}
+)
+
+const (
+	// HTTPRouteConditionAccepted - condition reported by the Gateway when it
+	// accepts the HTTPRoute
+	HTTPRouteConditionAccepted = "Accepted"
+	// HTTPRouteConditionResolvedRefs - condition reported by the Gateway when
+	// the backends of the HTTPRoute are resolved
+	HTTPRouteConditionResolvedRefs = "ResolvedRefs"
+)
+
+// HTTPRouteCondition - condition of the HTTPRoute reported by its Gateway
+type HTTPRouteCondition struct {
+	Type    string
+	Status  string
+	Message string
+}
+
+// HTTPRoute - returns the HTTPRoute exposing the dashboard
+func HTTPRoute(instance *horizonv1.Horizon) *unstructured.Unstructured {
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(HTTPRouteGVK)
+	route.SetName(instance.Name)
+	route.SetNamespace(instance.Namespace)
+	return route
+}
+
+// Gateway - returns the Gateway referenced by spec.override.gateway
+func Gateway(instance *horizonv1.Horizon) *unstructured.Unstructured {
+	gateway := &unstructured.Unstructured{}
+	gateway.SetGroupVersionKind(GatewayGVK)
+	gateway.SetName(instance.Spec.Override.Gateway.ParentRef.Name)
+	gateway.SetNamespace(getGatewayNamespace(instance))
+	return gateway
+}
+
+func getGatewayNamespace(instance *horizonv1.Horizon) string {
+	if ns := instance.Spec.Override.Gateway.ParentRef.Namespace; ns != "" {
+		return ns
+	}
+	return instance.Namespace
+}
+
+// GetServicePort - returns the port of the dashboard in the given Service,
+// the one a Route targets by name, zero when the Service doesn't expose it
+func GetServicePort(svc *corev1.Service) int32 {
+	for _, port := range svc.Spec.Ports {
+		if port.Name == ServiceName {
+			return port.Port
+		}
+	}
+	return 0
+}
+
+// MutateHTTPRoute - sets the labels, the annotations and the spec of the
+// HTTPRoute. The web root is forwarded to the given port of the Service. The
+// root path is redirected to it, like the RedirectMatch rule of httpd.conf,
+// only when the HTTPRoute lists host names: without them the HTTPRoute
+// matches every host name of the listener, and the redirect would take over
+// the root path of the other applications sharing the Gateway
+func MutateHTTPRoute(
+	route *unstructured.Unstructured,
+	instance *horizonv1.Horizon,
+	labels map[string]string,
+	port int32,
+) {
+	gw := instance.Spec.Override.Gateway
+	route.SetLabels(util.MergeStringMaps(route.GetLabels(), labels))
+	route.SetAnnotations(util.MergeStringMaps(
+		route.GetAnnotations(), gw.Annotations, GetAdditionalHostnamesAnnotation(instance)))
+
+	parentRef := map[string]any{
+		"group":     GatewayGVK.Group,
+		"kind":      GatewayGVK.Kind,
+		"name":      gw.ParentRef.Name,
+		"namespace": getGatewayNamespace(instance),
+	}
+	if gw.ParentRef.SectionName != "" {
+		parentRef["sectionName"] = gw.ParentRef.SectionName
+	}
+
+	webRoot := instance.Spec.GetWebRoot()
+	prefix := webRoot
+	if prefix == "" {
+		prefix = "/"
+	}
+	rules := []any{
+		map[string]any{
+			"matches": []any{
+				map[string]any{
+					"path": map[string]any{
+						"type":  "PathPrefix",
+						"value": prefix,
+					},
+				},
+			},
+			"backendRefs": []any{
+				map[string]any{
+					"name": instance.Name,
+					"port": int64(port),
+				},
+			},
+		},
+	}
+	if webRoot != "" && len(gw.Hostnames) > 0 {
+		rules = append([]any{
+			map[string]any{
+				"matches": []any{
+					map[string]any{
+						"path": map[string]any{
+							"type":  "Exact",
+							"value": "/",
+						},
+					},
+				},
+				"filters": []any{
+					map[string]any{
+						"type": "RequestRedirect",
+						"requestRedirect": map[string]any{
+							"path": map[string]any{
+								"type":            "ReplaceFullPath",
+								"replaceFullPath": webRoot,
+							},
+							"statusCode": int64(301),
+						},
+					},
+				},
+			},
+		}, rules...)
+	}
+
+	spec := map[string]any{
+		"parentRefs": []any{parentRef},
+		"rules":      rules,
+	}
+	// the additional host names are only matched when the HTTPRoute lists
+	// host names, it matches every host name of the listener otherwise
+	if len(gw.Hostnames) > 0 {
+		hostnames := make([]any, 0, len(gw.Hostnames)+len(instance.Spec.AdditionalHostnames))
+		for _, hostname := range gw.Hostnames {
+			hostnames = append(hostnames, hostname)
+		}
+		for _, hostname := range instance.Spec.AdditionalHostnames {
+			if !slices.Contains(gw.Hostnames, hostname) {
+				hostnames = append(hostnames, hostname)
+			}
+		}
+		spec["hostnames"] = hostnames
+	}
+	route.Object["spec"] = spec
+}
+
+// GetHTTPRouteNotReadyCondition - returns the first of the Accepted and
+// ResolvedRefs conditions reported by the referenced Gateway which is not
+// True, nil when both are True
+func GetHTTPRouteNotReadyCondition(
+	route *unstructured.Unstructured,
+	instance *horizonv1.Horizon,
+) *HTTPRouteCondition {
+	gw := instance.Spec.Override.Gateway
+	conditions := map[string]HTTPRouteCondition{}
+	parents, _, _ := unstructured.NestedSlice(route.Object, "status", "parents")
+	for _, p := range parents {
+		parent, ok := p.(map[string]any)
+		if !ok {
+			continue
+		}
+		name, _, _ := unstructured.NestedString(parent, "parentRef", "name")
+		namespace, _, _ := unstructured.NestedString(parent, "parentRef", "namespace")
+		sectionName, _, _ := unstructured.NestedString(parent, "parentRef", "sectionName")
+		if namespace == "" {
+			namespace = route.GetNamespace()
+		}
+		if name != gw.ParentRef.Name || namespace != getGatewayNamespace(instance) ||
+			sectionName != gw.ParentRef.SectionName {
+			continue
+		}
+		conds, _, _ := unstructured.NestedSlice(parent, "conditions")
+		for _, c := range conds {
+			cond, ok := c.(map[string]any)
+			if !ok {
+				continue
+			}
+			condType, _ := cond["type"].(string)
+			status, _ := cond["status"].(string)
+			message, _ := cond["message"].(string)
+			conditions[condType] = HTTPRouteCondition{Type: condType, Status: status, Message: message}
+		}
+	}
+
+	for _, condType := range []string{HTTPRouteConditionAccepted, HTTPRouteConditionResolvedRefs} {
+		cond, ok := conditions[condType]
+		if !ok {
+			return &HTTPRouteCondition{
+				Type:    condType,
+				Status:  "Unknown",
+				Message: "not reported by the Gateway yet",
+			}
+		}
+		if cond.Status != "True" {
+			return &cond
+		}
+	}
+	return nil
+}
+
+// GetHTTPRouteEndpoint - returns the URL of the dashboard exposed by the
+// HTTPRoute, built from the first host name of the HTTPRoute or the first
+// address of the Gateway. It is an empty string until the Gateway has an
+// address
+func GetHTTPRouteEndpoint(instance *horizonv1.Horizon, gateway *unstructured.Unstructured) string {
+	gw := instance.Spec.Override.Gateway
+	scheme := gw.Scheme
+	if scheme == "" {
+		scheme = "https"
+	}
+	if len(gw.Hostnames) > 0 && !strings.HasPrefix(gw.Hostnames[0], "*") {
+		return scheme + "://" + gw.Hostnames[0]
+	}
+	if gateway == nil {
+		return ""
+	}
+	addresses, _, _ := unstructured.NestedSlice(gateway.Object, "status", "addresses")
+	for _, a := range addresses {
+		address, ok := a.(map[string]any)
+		if !ok {
+			continue
+		}
+		host, _ := address["value"].(string)
+		if host == "" {
+			continue
+		}
+		if strings.Contains(host, ":") {
+			// IPv6 address
+			host = "[" + host + "]"
+		}
+		return scheme + "://" + host
+	}
+	return ""
+}
@@ -0,0 +1,132 @@
+package horizon
+
+import (
+	"testing"
+
+	horizonv1 "github.com/openstack-k8s-operators/horizon-operator/api/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func getGatewayInstance() *horizonv1.Horizon {
+	instance := &horizonv1.Horizon{}
+	instance.Name = "dashboard"
+	instance.Namespace = "openstack"
+	instance.Spec.Override.Gateway = &horizonv1.HorizonGatewaySpec{
+		ParentRef: horizonv1.HorizonGatewayParentRef{Name: "public", Namespace: "gateways"},
+		Hostnames: []string{"dashboard.example.com"},
+		Scheme:    "https",
+	}
+	return instance
+}
+
+func TestMutateHTTPRoute(t *testing.T) {
+	instance := getGatewayInstance()
+	route := HTTPRoute(instance)
+	assert.Equal(t, HTTPRouteGVK, route.GroupVersionKind())
+	MutateHTTPRoute(route, instance, map[string]string{"service": "horizon"}, HorizonSvcPort)
+	assert.Equal(t, "horizon", route.GetLabels()["service"])
+
+	parents, _, _ := unstructured.NestedSlice(route.Object, "spec", "parentRefs")
+	assert.Equal(t, []any{map[string]any{
+		"group":     "gateway.networking.k8s.io",
+		"kind":      "Gateway",
+		"name":      "public",
+		"namespace": "gateways",
+	}}, parents)
+	hostnames, _, _ := unstructured.NestedStringSlice(route.Object, "spec", "hostnames")
+	assert.Equal(t, []string{"dashboard.example.com"}, hostnames)
+
+	rules, _, _ := unstructured.NestedSlice(route.Object, "spec", "rules")
+	assert.Len(t, rules, 2)
+	redirect := rules[0].(map[string]any)
+	target, _, _ := unstructured.NestedString(
+		redirect["filters"].([]any)[0].(map[string]any), "requestRedirect", "path", "replaceFullPath")
+	assert.Equal(t, "/dashboard", target)
+	backend := rules[1].(map[string]any)["backendRefs"].([]any)[0].(map[string]any)
+	assert.Equal(t, "dashboard", backend["name"])
+	assert.Equal(t, int64(HorizonSvcPort), backend["port"])
+
+	// the backend is the port given from the Service
+	MutateHTTPRoute(route, instance, nil, 8080)
+	rules, _, _ = unstructured.NestedSlice(route.Object, "spec", "rules")
+	backend = rules[1].(map[string]any)["backendRefs"].([]any)[0].(map[string]any)
+	assert.Equal(t, int64(8080), backend["port"])
+
+	// without host names the root path of the listener is left alone
+	hosts := instance.Spec.Override.Gateway.Hostnames
+	instance.Spec.Override.Gateway.Hostnames = nil
+	MutateHTTPRoute(route, instance, nil, HorizonSvcPort)
+	rules, _, _ = unstructured.NestedSlice(route.Object, "spec", "rules")
+	assert.Len(t, rules, 1)
+	prefix, _, _ := unstructured.NestedString(
+		rules[0].(map[string]any)["matches"].([]any)[0].(map[string]any), "path", "value")
+	assert.Equal(t, "/dashboard", prefix)
+	instance.Spec.Override.Gateway.Hostnames = hosts
+
+	// the dashboard served at the root doesn't need a redirect
+	instance.Spec.WebRoot = "/"
+	MutateHTTPRoute(route, instance, nil, HorizonSvcPort)
+	rules, _, _ = unstructured.NestedSlice(route.Object, "spec", "rules")
+	assert.Len(t, rules, 1)
+	prefix, _, _ = unstructured.NestedString(
+		rules[0].(map[string]any)["matches"].([]any)[0].(map[string]any), "path", "value")
+	assert.Equal(t, "/", prefix)
+}
+
+func TestGetHTTPRouteNotReadyCondition(t *testing.T) {
+	instance := getGatewayInstance()
+	route := HTTPRoute(instance)
+
+	cond := GetHTTPRouteNotReadyCondition(route, instance)
+	assert.Equal(t, HTTPRouteConditionAccepted, cond.Type)
+	assert.Equal(t, "Unknown", cond.Status)
+
+	route.Object["status"] = map[string]any{
+		"parents": []any{
+			map[string]any{
+				"parentRef": map[string]any{"name": "other", "namespace": "gateways"},
+				"conditions": []any{
+					map[string]any{"type": "Accepted", "status": "True"},
+					map[string]any{"type": "ResolvedRefs", "status": "True"},
+				},
+			},
+			map[string]any{
+				"parentRef": map[string]any{"name": "public", "namespace": "gateways"},
+				"conditions": []any{
+					map[string]any{"type": "Accepted", "status": "True"},
+					map[string]any{"type": "ResolvedRefs", "status": "False", "message": "service not found"},
+				},
+			},
+		},
+	}
+	cond = GetHTTPRouteNotReadyCondition(route, instance)
+	assert.Equal(t, &HTTPRouteCondition{Type: "ResolvedRefs", Status: "False", Message: "service not found"}, cond)
+
+	assert.NoError(t, unstructured.SetNestedField(route.Object, []any{
+		map[string]any{
+			"parentRef": map[string]any{"name": "public", "namespace": "gateways"},
+			"conditions": []any{
+				map[string]any{"type": "Accepted", "status": "True"},
+				map[string]any{"type": "ResolvedRefs", "status": "True"},
+			},
+		},
+	}, "status", "parents"))
+	assert.Nil(t, GetHTTPRouteNotReadyCondition(route, instance))
+}
+
+func TestGetHTTPRouteEndpoint(t *testing.T) {
+	instance := getGatewayInstance()
+	assert.Equal(t, "https://dashboard.example.com", GetHTTPRouteEndpoint(instance, nil))
+
+	instance.Spec.Override.Gateway.Hostnames = nil
+	instance.Spec.Override.Gateway.Scheme = "http"
+	gateway := Gateway(instance)
+	assert.Equal(t, "gateways", gateway.GetNamespace())
+	assert.Empty(t, GetHTTPRouteEndpoint(instance, gateway))
+
+	gateway.Object["status"] = map[string]any{
+		"addresses": []any{map[string]any{"type": "IPAddress", "value": "fd00::20"}},
+	}
+	assert.Equal(t, "http://[fd00::20]", GetHTTPRouteEndpoint(instance, gateway))
+}
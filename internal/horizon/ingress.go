@@ -0,0 +1,236 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package horizon
+
+import (
+	"strings"
+
+	horizonv1 "github.com/openstack-k8s-operators/horizon-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// The OpenShift Route is handled as an unstructured object, the operator
+// creates an Ingress on the clusters not serving the route.openshift.io API
+var (
+	// RouteGVK -
+	RouteGVK = schema.GroupVersionKind{Group: "route.openshift.io", Version: "v1", Kind: "Route"}
+)
+
+const (
+	// IngressBackendProtocolAnnotation - tells the ingress-nginx controller
+	// to reach the dashboard pods over HTTPS when they serve TLS
+	IngressBackendProtocolAnnotation = "nginx.ingress.kubernetes.io/backend-protocol"
+)
+
+// RouteTLS - certificates embedded in the Route, empty when the default
+// certificate of the router is used
+type RouteTLS struct {
+	Certificate              string
+	Key                      string
+	CACertificate            string
+	DestinationCACertificate string
+}
+
+// Route - returns the Route exposing the dashboard
+func Route(instance *horizonv1.Horizon) *unstructured.Unstructured {
+	route := &unstructured.Unstructured{}
+	route.SetGroupVersionKind(RouteGVK)
+	route.SetName(instance.Name)
+	route.SetNamespace(instance.Namespace)
+	return route
+}
+
+// MutateRoute - sets the labels, the annotations and the spec of the Route.
+// The host generated by the router is kept when no hostname is requested
+func MutateRoute(
+	route *unstructured.Unstructured,
+	instance *horizonv1.Horizon,
+	labels map[string]string,
+	certs RouteTLS,
+) {
+	route.SetLabels(util.MergeStringMaps(route.GetLabels(), labels))
+	route.SetAnnotations(util.MergeStringMaps(
+		route.GetAnnotations(), instance.Spec.Ingress.Annotations, GetAdditionalHostnamesAnnotation(instance)))
+
+	termination := instance.Spec.GetIngressTLSTermination()
+	routeTLS := map[string]any{
+		"termination":                   string(termination),
+		"insecureEdgeTerminationPolicy": "Redirect",
+	}
+	if termination != horizonv1.TLSTerminationPassthrough {
+		for key, value := range map[string]string{
+			"certificate":   certs.Certificate,
+			"key":           certs.Key,
+			"caCertificate": certs.CACertificate,
+		} {
+			if value != "" {
+				routeTLS[key] = value
+			}
+		}
+	}
+	if termination == horizonv1.TLSTerminationReencrypt && certs.DestinationCACertificate != "" {
+		routeTLS["destinationCACertificate"] = certs.DestinationCACertificate
+	}
+
+	spec := map[string]any{
+		"to": map[string]any{
+			"kind":   "Service",
+			"name":   instance.Name,
+			"weight": int64(100),
+		},
+		"port": map[string]any{
+			"targetPort": ServiceName,
+		},
+		"tls": routeTLS,
+	}
+	host := instance.Spec.Ingress.Hostname
+	if host == "" {
+		host, _, _ = unstructured.NestedString(route.Object, "spec", "host")
+	}
+	if host != "" {
+		spec["host"] = host
+	}
+	route.Object["spec"] = spec
+}
+
+// GetRouteHost - returns the host name of the Route, set by the router when
+// none was requested
+func GetRouteHost(route *unstructured.Unstructured) string {
+	if host, _, _ := unstructured.NestedString(route.Object, "spec", "host"); host != "" {
+		return host
+	}
+	ingresses, _, _ := unstructured.NestedSlice(route.Object, "status", "ingress")
+	for _, i := range ingresses {
+		if ingress, ok := i.(map[string]any); ok {
+			if host, _ := ingress["host"].(string); host != "" {
+				return host
+			}
+		}
+	}
+	return ""
+}
+
+// Ingress - returns the Ingress exposing the dashboard
+func Ingress(instance *horizonv1.Horizon) *networkingv1.Ingress {
+	return &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      instance.Name,
+			Namespace: instance.Namespace,
+		},
+	}
+}
+
+// MutateIngress - sets the labels, the annotations and the spec of the
+// Ingress, with a rule for the host name and each additional host name.
+// The backend protocol is set to HTTPS when the pods serve TLS, unless the
+// annotation is set in spec.ingress.annotations
+func MutateIngress(
+	ingress *networkingv1.Ingress,
+	instance *horizonv1.Horizon,
+	labels map[string]string,
+) {
+	backendProtocol := map[string]string{}
+	if instance.Spec.TLS.Enabled() {
+		backendProtocol[IngressBackendProtocolAnnotation] = "HTTPS"
+	}
+	ingress.Labels = util.MergeStringMaps(ingress.Labels, labels)
+	ingress.Annotations = util.MergeStringMaps(
+		ingress.Annotations, instance.Spec.Ingress.Annotations, GetAdditionalHostnamesAnnotation(instance), backendProtocol)
+
+	pathType := networkingv1.PathTypePrefix
+	ruleValue := networkingv1.IngressRuleValue{
+		HTTP: &networkingv1.HTTPIngressRuleValue{
+			Paths: []networkingv1.HTTPIngressPath{
+				{
+					Path:     "/",
+					PathType: &pathType,
+					Backend: networkingv1.IngressBackend{
+						Service: &networkingv1.IngressServiceBackend{
+							Name: instance.Name,
+							Port: networkingv1.ServiceBackendPort{
+								Name: ServiceName,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	hosts := []string{}
+	if instance.Spec.Ingress.Hostname != "" {
+		hosts = append(hosts, instance.Spec.Ingress.Hostname)
+	}
+	hosts = append(hosts, instance.Spec.AdditionalHostnames...)
+
+	ingress.Spec = networkingv1.IngressSpec{
+		IngressClassName: instance.Spec.Ingress.IngressClassName,
+		Rules: []networkingv1.IngressRule{
+			{
+				Host:             instance.Spec.Ingress.Hostname,
+				IngressRuleValue: ruleValue,
+			},
+		},
+	}
+	for _, hostname := range instance.Spec.AdditionalHostnames {
+		ingress.Spec.Rules = append(ingress.Spec.Rules, networkingv1.IngressRule{
+			Host:             hostname,
+			IngressRuleValue: *ruleValue.DeepCopy(),
+		})
+	}
+	if instance.Spec.Ingress.TLSSecretName != "" {
+		ingress.Spec.TLS = []networkingv1.IngressTLS{
+			{
+				SecretName: instance.Spec.Ingress.TLSSecretName,
+			},
+		}
+		if len(hosts) > 0 {
+			ingress.Spec.TLS[0].Hosts = hosts
+		}
+	}
+}
+
+// GetIngressEndpoint - returns the URL of the dashboard exposed by the
+// Ingress, an empty string until the Ingress has a host name or an address
+func GetIngressEndpoint(ingress *networkingv1.Ingress) string {
+	host := ""
+	if len(ingress.Spec.Rules) > 0 {
+		host = ingress.Spec.Rules[0].Host
+	}
+	for _, lb := range ingress.Status.LoadBalancer.Ingress {
+		if host != "" {
+			break
+		}
+		host = lb.Hostname
+		if host == "" && lb.IP != "" {
+			host = lb.IP
+			if strings.Contains(host, ":") {
+				// IPv6 address
+				host = "[" + host + "]"
+			}
+		}
+	}
+	if host == "" {
+		return ""
+	}
+	if len(ingress.Spec.TLS) > 0 {
+		return "https://" + host
+	}
+	return "http://" + host
+}
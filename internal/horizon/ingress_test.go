@@ -0,0 +1,121 @@
+package horizon
+
+import (
+	"testing"
+
+	horizonv1 "github.com/openstack-k8s-operators/horizon-operator/api/v1beta1"
+	"github.com/stretchr/testify/assert"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+)
+
+func TestMutateRoute(t *testing.T) {
+	instance := &horizonv1.Horizon{}
+	instance.Name = "dashboard"
+	instance.Namespace = "openstack"
+	instance.Spec.Ingress = &horizonv1.HorizonIngressSpec{
+		Annotations: map[string]string{"haproxy.router.openshift.io/timeout": "60s"},
+	}
+
+	route := Route(instance)
+	assert.Equal(t, RouteGVK, route.GroupVersionKind())
+	MutateRoute(route, instance, map[string]string{"service": "horizon"}, RouteTLS{Certificate: "cert", Key: "key"})
+	assert.Equal(t, "60s", route.GetAnnotations()["haproxy.router.openshift.io/timeout"])
+	assert.Equal(t, "horizon", route.GetLabels()["service"])
+
+	name, _, _ := unstructured.NestedString(route.Object, "spec", "to", "name")
+	assert.Equal(t, "dashboard", name)
+	termination, _, _ := unstructured.NestedString(route.Object, "spec", "tls", "termination")
+	assert.Equal(t, "edge", termination)
+	cert, _, _ := unstructured.NestedString(route.Object, "spec", "tls", "certificate")
+	assert.Equal(t, "cert", cert)
+	_, found, _ := unstructured.NestedString(route.Object, "spec", "host")
+	assert.False(t, found)
+
+	// the host generated by the router is kept
+	assert.Empty(t, GetRouteHost(route))
+	route.Object["status"] = map[string]any{
+		"ingress": []any{map[string]any{"host": "dashboard-openstack.apps.example.com"}},
+	}
+	assert.Equal(t, "dashboard-openstack.apps.example.com", GetRouteHost(route))
+	assert.NoError(t, unstructured.SetNestedField(route.Object, "dashboard-openstack.apps.example.com", "spec", "host"))
+	MutateRoute(route, instance, nil, RouteTLS{})
+	host, _, _ := unstructured.NestedString(route.Object, "spec", "host")
+	assert.Equal(t, "dashboard-openstack.apps.example.com", host)
+
+	// passthrough routes don't embed certificates
+	instance.Spec.Ingress.Hostname = "dashboard.example.com"
+	instance.Spec.Ingress.TLSTermination = horizonv1.TLSTerminationPassthrough
+	MutateRoute(route, instance, nil, RouteTLS{Certificate: "cert", DestinationCACertificate: "ca"})
+	assert.Equal(t, "dashboard.example.com", GetRouteHost(route))
+	routeTLS, _, _ := unstructured.NestedStringMap(route.Object, "spec", "tls")
+	assert.Equal(t, map[string]string{
+		"termination":                   "passthrough",
+		"insecureEdgeTerminationPolicy": "Redirect",
+	}, routeTLS)
+}
+
+func TestMutateIngress(t *testing.T) {
+	instance := &horizonv1.Horizon{}
+	instance.Name = "dashboard"
+	instance.Namespace = "openstack"
+	instance.Spec.Ingress = &horizonv1.HorizonIngressSpec{
+		Hostname:      "dashboard.example.com",
+		TLSSecretName: "dashboard-cert",
+	}
+
+	ingress := Ingress(instance)
+	MutateIngress(ingress, instance, map[string]string{"service": "horizon"})
+	assert.Equal(t, "horizon", ingress.Labels["service"])
+	assert.Len(t, ingress.Spec.Rules, 1)
+	assert.Equal(t, "dashboard.example.com", ingress.Spec.Rules[0].Host)
+	backend := ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service
+	assert.Equal(t, "dashboard", backend.Name)
+	assert.Equal(t, ServiceName, backend.Port.Name)
+	assert.Equal(t, []networkingv1.IngressTLS{
+		{Hosts: []string{"dashboard.example.com"}, SecretName: "dashboard-cert"},
+	}, ingress.Spec.TLS)
+	assert.Equal(t, "https://dashboard.example.com", GetIngressEndpoint(ingress))
+
+	// a rule is added for each additional host name
+	instance.Spec.AdditionalHostnames = []string{"cloud.example.org"}
+	MutateIngress(ingress, instance, nil)
+	assert.Len(t, ingress.Spec.Rules, 2)
+	assert.Equal(t, "cloud.example.org", ingress.Spec.Rules[1].Host)
+	assert.Equal(t, "dashboard", ingress.Spec.Rules[1].HTTP.Paths[0].Backend.Service.Name)
+	assert.Equal(t, []string{"dashboard.example.com", "cloud.example.org"}, ingress.Spec.TLS[0].Hosts)
+	assert.Equal(t, "cloud.example.org", ingress.Annotations[horizonv1.AdditionalHostnamesAnnotation])
+	assert.Equal(t, "https://dashboard.example.com", GetIngressEndpoint(ingress))
+	assert.NotContains(t, ingress.Annotations, IngressBackendProtocolAnnotation)
+
+	// the pods are reached over HTTPS when they serve TLS
+	instance.Spec.TLS.SecretName = ptr.To("dashboard-internal-cert")
+	ingress = Ingress(instance)
+	MutateIngress(ingress, instance, nil)
+	assert.Equal(t, "HTTPS", ingress.Annotations[IngressBackendProtocolAnnotation])
+
+	// unless the backend protocol is requested in the annotations
+	instance.Spec.Ingress.Annotations = map[string]string{IngressBackendProtocolAnnotation: "GRPCS"}
+	ingress = Ingress(instance)
+	MutateIngress(ingress, instance, nil)
+	assert.Equal(t, "GRPCS", ingress.Annotations[IngressBackendProtocolAnnotation])
+}
+
+func TestGetIngressEndpoint(t *testing.T) {
+	ingress := &networkingv1.Ingress{
+		Spec: networkingv1.IngressSpec{
+			Rules: []networkingv1.IngressRule{{}},
+		},
+	}
+	assert.Empty(t, GetIngressEndpoint(ingress))
+
+	ingress.Status.LoadBalancer.Ingress = []networkingv1.IngressLoadBalancerIngress{{IP: "fd00::10"}}
+	assert.Equal(t, "http://[fd00::10]", GetIngressEndpoint(ingress))
+
+	ingress.Status.LoadBalancer.Ingress = []networkingv1.IngressLoadBalancerIngress{
+		{IP: "192.0.2.10"},
+		{Hostname: "lb.example.com"},
+	}
+	assert.Equal(t, "http://192.0.2.10", GetIngressEndpoint(ingress))
+}
@@ -0,0 +1,60 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package horizon
+
+import (
+	horizonv1 "github.com/openstack-k8s-operators/horizon-operator/api/v1beta1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// DefaultMaxUnavailable - number of horizon pods that can be disrupted at a
+// time when the podDisruptionBudget section doesn't override it
+const DefaultMaxUnavailable = 1
+
+// PodDisruptionBudget - returns the PodDisruptionBudget protecting the horizon
+// pods selected by labels
+func PodDisruptionBudget(
+	instance *horizonv1.Horizon,
+	labels map[string]string,
+) *policyv1.PodDisruptionBudget {
+	spec := policyv1.PodDisruptionBudgetSpec{
+		Selector: &metav1.LabelSelector{
+			MatchLabels: labels,
+		},
+	}
+
+	pdb := instance.Spec.PodDisruptionBudget
+	switch {
+	case pdb != nil && pdb.MinAvailable != nil:
+		spec.MinAvailable = pdb.MinAvailable
+	case pdb != nil && pdb.MaxUnavailable != nil:
+		spec.MaxUnavailable = pdb.MaxUnavailable
+	default:
+		maxUnavailable := intstr.FromInt32(DefaultMaxUnavailable)
+		spec.MaxUnavailable = &maxUnavailable
+	}
+
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      instance.Name,
+			Namespace: instance.Namespace,
+			Labels:    labels,
+		},
+		Spec: spec,
+	}
+}
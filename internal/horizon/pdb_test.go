@@ -0,0 +1,57 @@
+package horizon
+
+import (
+	"testing"
+
+	horizonv1 "github.com/openstack-k8s-operators/horizon-operator/api/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+)
+
+func TestPodDisruptionBudget(t *testing.T) {
+
+	testCases := []struct {
+		name                   string
+		pdb                    *horizonv1.HorizonPodDisruptionBudgetSpec
+		expectedMinAvailable   *intstr.IntOrString
+		expectedMaxUnavailable *intstr.IntOrString
+	}{
+		{
+			name:                   "Default",
+			pdb:                    nil,
+			expectedMaxUnavailable: ptr.To(intstr.FromInt32(1)),
+		},
+		{
+			name: "MinAvailable",
+			pdb: &horizonv1.HorizonPodDisruptionBudgetSpec{
+				MinAvailable: ptr.To(intstr.FromString("50%")),
+			},
+			expectedMinAvailable: ptr.To(intstr.FromString("50%")),
+		},
+		{
+			name: "MaxUnavailable",
+			pdb: &horizonv1.HorizonPodDisruptionBudgetSpec{
+				MaxUnavailable: ptr.To(intstr.FromInt32(2)),
+			},
+			expectedMaxUnavailable: ptr.To(intstr.FromInt32(2)),
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := &horizonv1.Horizon{
+				Spec: horizonv1.HorizonSpec{
+					HorizonSpecCore: horizonv1.HorizonSpecCore{
+						PodDisruptionBudget: tt.pdb,
+					},
+				},
+			}
+			labels := map[string]string{"service": ServiceName}
+			pdb := PodDisruptionBudget(instance, labels)
+			assert.Equal(t, labels, pdb.Spec.Selector.MatchLabels)
+			assert.Equal(t, tt.expectedMinAvailable, pdb.Spec.MinAvailable)
+			assert.Equal(t, tt.expectedMaxUnavailable, pdb.Spec.MaxUnavailable)
+		})
+	}
+}
@@ -0,0 +1,59 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package horizon
+
+import (
+	"maps"
+	"slices"
+
+	horizonv1 "github.com/openstack-k8s-operators/horizon-operator/api/v1beta1"
+)
+
+// GetEnabledServices - resolves the state of each dashboard plugin as "yes" or
+// "no", the values expected by the ENABLE_<PLUGIN> variables of the horizon
+// container. A plugin follows its spec.plugins mode, or the presence of its
+// KeystoneService (keystoneServices) when the mode is auto or not set
+func GetEnabledServices(
+	plugins map[string]horizonv1.HorizonPluginMode,
+	keystoneServices map[string]bool,
+) map[string]string {
+	res := map[string]string{}
+	for _, plugin := range horizonv1.DashboardPlugins {
+		enabled := keystoneServices[plugin]
+		switch plugins[plugin] {
+		case horizonv1.PluginModeEnabled:
+			enabled = true
+		case horizonv1.PluginModeDisabled:
+			enabled = false
+		}
+		res[plugin] = "no"
+		if enabled {
+			res[plugin] = "yes"
+		}
+	}
+	return res
+}
+
+// GetEnabledPlugins - returns the sorted list of the enabled plugins
+func GetEnabledPlugins(enabledServices map[string]string) []string {
+	res := []string{}
+	for _, plugin := range slices.Sorted(maps.Keys(enabledServices)) {
+		if enabledServices[plugin] == "yes" {
+			res = append(res, plugin)
+		}
+	}
+	return res
+}
@@ -0,0 +1,56 @@
+package horizon
+
+import (
+	"testing"
+
+	horizonv1 "github.com/openstack-k8s-operators/horizon-operator/api/v1beta1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetEnabledServices(t *testing.T) {
+
+	testCases := []struct {
+		name             string
+		plugins          map[string]horizonv1.HorizonPluginMode
+		keystoneServices map[string]bool
+		expectedEnabled  []string
+	}{
+		{
+			name:             "No KeystoneService",
+			plugins:          nil,
+			keystoneServices: map[string]bool{},
+			expectedEnabled:  []string{},
+		},
+		{
+			name:    "Auto detected",
+			plugins: nil,
+			keystoneServices: map[string]bool{
+				"heat":    true,
+				"octavia": true,
+			},
+			expectedEnabled: []string{"heat", "octavia"},
+		},
+		{
+			name: "Overrides",
+			plugins: map[string]horizonv1.HorizonPluginMode{
+				"designate": horizonv1.PluginModeEnabled,
+				"heat":      horizonv1.PluginModeDisabled,
+				"octavia":   horizonv1.PluginModeAuto,
+			},
+			keystoneServices: map[string]bool{
+				"heat":    true,
+				"octavia": true,
+			},
+			expectedEnabled: []string{"designate", "octavia"},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			enabledServices := GetEnabledServices(tt.plugins, tt.keystoneServices)
+			// every plugin is explicitly enabled or disabled
+			assert.Len(t, enabledServices, len(horizonv1.DashboardPlugins))
+			assert.Equal(t, tt.expectedEnabled, GetEnabledPlugins(enabledServices))
+		})
+	}
+}
@@ -0,0 +1,107 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package horizon
+
+import (
+	"fmt"
+	"maps"
+	"path"
+	"slices"
+
+	horizonv1 "github.com/openstack-k8s-operators/horizon-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// PolicyConfigPath - directory where the policy ConfigMaps are mounted before
+// kolla copies them to PolicyFilesPath
+const PolicyConfigPath = "/var/lib/config-data/policies"
+
+// PolicyFile - policy file of an OpenStack service referenced in spec.policies
+type PolicyFile struct {
+	// Service - service type used as POLICY_FILES key
+	Service string
+	// ConfigMapName - ConfigMap holding the policy file
+	ConfigMapName string
+	// Key - ConfigMap key holding the policy file
+	Key string
+	// FileName - name of the policy file in PolicyFilesPath
+	FileName string
+}
+
+// Source - path of the mounted policy file
+func (p PolicyFile) Source() string {
+	return path.Join(PolicyConfigPath, p.Service, p.FileName)
+}
+
+// Dest - path of the policy file in PolicyFilesPath
+func (p PolicyFile) Dest() string {
+	return path.Join(PolicyFilesPath, p.FileName)
+}
+
+// GetPolicyFiles - returns the policy files referenced in spec.policies,
+// sorted by service type
+func GetPolicyFiles(policies map[string]horizonv1.HorizonPolicySource) []PolicyFile {
+	files := []PolicyFile{}
+	for _, service := range slices.Sorted(maps.Keys(policies)) {
+		src := policies[service]
+		files = append(files, PolicyFile{
+			Service:       service,
+			ConfigMapName: src.ConfigMapName,
+			Key:           src.Key,
+			FileName:      fmt.Sprintf("%s_policy.yaml", service),
+		})
+	}
+	return files
+}
+
+// getPolicyVolumes - a Volume for each ConfigMap referenced in spec.policies
+func getPolicyVolumes(policies map[string]horizonv1.HorizonPolicySource) []corev1.Volume {
+	var config0640AccessMode int32 = 0640
+	res := []corev1.Volume{}
+	for _, p := range GetPolicyFiles(policies) {
+		res = append(res, corev1.Volume{
+			Name: "policy-" + p.Service,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					DefaultMode: &config0640AccessMode,
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: p.ConfigMapName,
+					},
+					Items: []corev1.KeyToPath{
+						{
+							Key:  p.Key,
+							Path: p.FileName,
+						},
+					},
+				},
+			},
+		})
+	}
+	return res
+}
+
+// getPolicyVolumeMounts - mounts the policy files in PolicyConfigPath
+func getPolicyVolumeMounts(policies map[string]horizonv1.HorizonPolicySource) []corev1.VolumeMount {
+	res := []corev1.VolumeMount{}
+	for _, p := range GetPolicyFiles(policies) {
+		res = append(res, corev1.VolumeMount{
+			Name:      "policy-" + p.Service,
+			MountPath: path.Join(PolicyConfigPath, p.Service),
+			ReadOnly:  true,
+		})
+	}
+	return res
+}
@@ -0,0 +1,79 @@
+package horizon
+
+import (
+	"testing"
+
+	horizonv1 "github.com/openstack-k8s-operators/horizon-operator/api/v1beta1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestPolicyVolumes(t *testing.T) {
+
+	var defaultMode int32 = 0640
+	testCases := []struct {
+		name            string
+		policies        map[string]horizonv1.HorizonPolicySource
+		expectedFiles   []PolicyFile
+		expectedVolumes []corev1.Volume
+		expectedMounts  []corev1.VolumeMount
+	}{
+		{
+			name:            "No policies",
+			policies:        nil,
+			expectedFiles:   []PolicyFile{},
+			expectedVolumes: []corev1.Volume{},
+			expectedMounts:  []corev1.VolumeMount{},
+		},
+		{
+			name: "Compute and network policies",
+			policies: map[string]horizonv1.HorizonPolicySource{
+				"network": {ConfigMapName: "neutron-policy", Key: "policy.json"},
+				"compute": {ConfigMapName: "nova-policy", Key: "policy.yaml"},
+			},
+			expectedFiles: []PolicyFile{
+				{Service: "compute", ConfigMapName: "nova-policy", Key: "policy.yaml", FileName: "compute_policy.yaml"},
+				{Service: "network", ConfigMapName: "neutron-policy", Key: "policy.json", FileName: "network_policy.yaml"},
+			},
+			expectedVolumes: []corev1.Volume{
+				{
+					Name: "policy-compute",
+					VolumeSource: corev1.VolumeSource{
+						ConfigMap: &corev1.ConfigMapVolumeSource{
+							DefaultMode:          &defaultMode,
+							LocalObjectReference: corev1.LocalObjectReference{Name: "nova-policy"},
+							Items:                []corev1.KeyToPath{{Key: "policy.yaml", Path: "compute_policy.yaml"}},
+						},
+					},
+				},
+				{
+					Name: "policy-network",
+					VolumeSource: corev1.VolumeSource{
+						ConfigMap: &corev1.ConfigMapVolumeSource{
+							DefaultMode:          &defaultMode,
+							LocalObjectReference: corev1.LocalObjectReference{Name: "neutron-policy"},
+							Items:                []corev1.KeyToPath{{Key: "policy.json", Path: "network_policy.yaml"}},
+						},
+					},
+				},
+			},
+			expectedMounts: []corev1.VolumeMount{
+				{Name: "policy-compute", MountPath: "/var/lib/config-data/policies/compute", ReadOnly: true},
+				{Name: "policy-network", MountPath: "/var/lib/config-data/policies/network", ReadOnly: true},
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			files := GetPolicyFiles(tt.policies)
+			assert.Equal(t, tt.expectedFiles, files)
+			for _, f := range files {
+				assert.Equal(t, "/var/lib/config-data/policies/"+f.Service+"/"+f.FileName, f.Source())
+				assert.Equal(t, "/etc/openstack-dashboard/"+f.FileName, f.Dest())
+			}
+			assert.Equal(t, tt.expectedVolumes, getPolicyVolumes(tt.policies))
+			assert.Equal(t, tt.expectedMounts, getPolicyVolumeMounts(tt.policies))
+		})
+	}
+}
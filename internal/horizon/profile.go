@@ -0,0 +1,66 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package horizon
+
+import (
+	"path"
+
+	horizonv1 "github.com/openstack-k8s-operators/horizon-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/service"
+)
+
+const (
+	// DashboardEnabledPath - directory of the pluggable dashboard settings
+	// overriding the ones shipped with horizon
+	DashboardEnabledPath = "/usr/share/openstack-dashboard/openstack_dashboard/local/enabled"
+
+	// ProfileEnabledFile - pluggable dashboard settings file rendered for
+	// the profile, loaded after the files shipped with horizon
+	ProfileEnabledFile = "_9010_horizon_profile.py"
+)
+
+// GetProfileEnabledFileDest - returns where kolla places ProfileEnabledFile
+func GetProfileEnabledFileDest() string {
+	return path.Join(DashboardEnabledPath, ProfileEnabledFile)
+}
+
+// GetDisabledDashboard - returns the dashboard hidden by the profile, an
+// empty string when every dashboard is displayed
+func GetDisabledDashboard(profile horizonv1.HorizonProfile) string {
+	if profile == horizonv1.ProfileTenant {
+		return "admin"
+	}
+	return ""
+}
+
+// GetEndpointType - returns the OPENSTACK_ENDPOINT_TYPE or
+// SECONDARY_ENDPOINT_TYPE value matching a service catalog interface, an
+// empty string when the interface is not set
+func GetEndpointType(iface horizonv1.HorizonEndpointInterface) string {
+	if iface == "" {
+		return ""
+	}
+	return string(iface) + "URL"
+}
+
+// GetServiceEndpoint - returns the endpoint type of the horizon Service, the
+// admin dashboard is not meant to be exposed outside of the cluster
+func GetServiceEndpoint(profile horizonv1.HorizonProfile) service.Endpoint {
+	if profile == horizonv1.ProfileAdmin {
+		return service.EndpointInternal
+	}
+	return service.EndpointPublic
+}
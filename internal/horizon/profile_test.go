@@ -0,0 +1,51 @@
+package horizon
+
+import (
+	"testing"
+
+	horizonv1 "github.com/openstack-k8s-operators/horizon-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/service"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProfile(t *testing.T) {
+
+	testCases := []struct {
+		name             string
+		profile          horizonv1.HorizonProfile
+		expectedDisabled string
+		expectedService  service.Endpoint
+	}{
+		{
+			name:             "Tenant",
+			profile:          horizonv1.ProfileTenant,
+			expectedDisabled: "admin",
+			expectedService:  service.EndpointPublic,
+		},
+		{
+			name:             "Admin",
+			profile:          horizonv1.ProfileAdmin,
+			expectedDisabled: "",
+			expectedService:  service.EndpointInternal,
+		},
+		{
+			name:             "Full",
+			profile:          horizonv1.ProfileFull,
+			expectedDisabled: "",
+			expectedService:  service.EndpointPublic,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedDisabled, GetDisabledDashboard(tc.profile))
+			assert.Equal(t, tc.expectedService, GetServiceEndpoint(tc.profile))
+		})
+	}
+}
+
+func TestGetEndpointType(t *testing.T) {
+	assert.Equal(t, "publicURL", GetEndpointType(horizonv1.EndpointInterfacePublic))
+	assert.Equal(t, "internalURL", GetEndpointType(horizonv1.EndpointInterfaceInternal))
+	assert.Equal(t, "", GetEndpointType(""))
+}
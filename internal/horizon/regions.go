@@ -0,0 +1,155 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package horizon
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	horizonv1 "github.com/openstack-k8s-operators/horizon-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/tls"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// RegionCABundlePath - directory where the CA bundles of the regions
+	// are mounted
+	RegionCABundlePath = "/var/lib/config-data/ca-bundles"
+
+	// RegionCABundleDir - emptyDir where region_ca_setup writes
+	// RegionCABundleFile, it runs as the apache user before kolla_start
+	// and can't write to /etc
+	RegionCABundleDir = "/run/openstack-dashboard/ca-bundle"
+
+	// RegionCABundleFile - CA bundle built by region_ca_setup from the CA
+	// bundle of the pod and the ones of the regions (OPENSTACK_SSL_CACERT)
+	RegionCABundleFile = RegionCABundleDir + "/ca-bundle.pem"
+
+	// regionCABundleVolume - name of the emptyDir holding RegionCABundleFile
+	regionCABundleVolume = "region-ca-bundle"
+
+	// RegionCAServiceCommand - ServiceCommand building RegionCABundleFile
+	// before starting kolla
+	RegionCAServiceCommand = "/usr/local/bin/kolla_theme_setup && " +
+		"/usr/local/bin/container-scripts/region_ca_setup && /usr/local/bin/kolla_start"
+)
+
+// Region - a region rendered in AVAILABLE_REGIONS
+type Region struct {
+	Name        string
+	KeystoneURL string
+}
+
+// GetAvailableRegions - returns the entries of AVAILABLE_REGIONS, formatted
+// as Python literals so the names of the regions can't break out of their
+// string
+func GetAvailableRegions(regions []Region) []string {
+	res := []string{}
+	for _, region := range regions {
+		res = append(res, fmt.Sprintf("(%s, %s)",
+			pythonString(region.KeystoneURL), pythonString(region.Name)))
+	}
+	return res
+}
+
+// GetRegionKeystoneURL - returns the Keystone v3 URL of a region, the
+// version suffix is added when missing
+func GetRegionKeystoneURL(keystoneURL string) string {
+	u := strings.TrimSuffix(strings.TrimSuffix(keystoneURL, "/"), "/v3")
+	return u + "/v3"
+}
+
+// GetExtraMounts - returns the extraMounts mounted in the pods. When regions
+// are listed, the extraMounts of another region are skipped
+func GetExtraMounts(instance *horizonv1.Horizon) []horizonv1.HorizonExtraVolMounts {
+	if len(instance.Spec.Regions) == 0 {
+		return instance.Spec.ExtraMounts
+	}
+	regions := instance.Spec.GetRegionNames()
+	res := []horizonv1.HorizonExtraVolMounts{}
+	for _, exv := range instance.Spec.ExtraMounts {
+		if exv.Region == "" || slices.Contains(regions, exv.Region) {
+			res = append(res, exv)
+		}
+	}
+	return res
+}
+
+// GetRegionCABundleSecretNames - returns the sorted names of the Secrets
+// holding the CA bundles of the regions
+func GetRegionCABundleSecretNames(regions []horizonv1.HorizonRegionSpec) []string {
+	res := []string{}
+	for _, region := range regions {
+		if region.CaBundleSecretName != "" && !slices.Contains(res, region.CaBundleSecretName) {
+			res = append(res, region.CaBundleSecretName)
+		}
+	}
+	slices.Sort(res)
+	return res
+}
+
+// getRegionCAVolumes - returns the Volumes of the CA bundles of the regions,
+// along with the emptyDir of the bundle concatenating them
+func getRegionCAVolumes(regions []horizonv1.HorizonRegionSpec) []corev1.Volume {
+	var config0644AccessMode int32 = 0644
+	res := []corev1.Volume{}
+	secretNames := GetRegionCABundleSecretNames(regions)
+	if len(secretNames) > 0 {
+		res = append(res, corev1.Volume{
+			Name: regionCABundleVolume,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		})
+	}
+	for i, secretName := range secretNames {
+		res = append(res, corev1.Volume{
+			Name: fmt.Sprintf("region-ca-bundle-%d", i),
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  secretName,
+					DefaultMode: &config0644AccessMode,
+				},
+			},
+		})
+	}
+	return res
+}
+
+// getRegionCAVolumeMounts - returns the VolumeMounts placing each CA bundle
+// in RegionCABundlePath, named after its Secret, and the emptyDir in
+// RegionCABundleDir
+func getRegionCAVolumeMounts(regions []horizonv1.HorizonRegionSpec) []corev1.VolumeMount {
+	res := []corev1.VolumeMount{}
+	secretNames := GetRegionCABundleSecretNames(regions)
+	if len(secretNames) > 0 {
+		res = append(res, corev1.VolumeMount{
+			Name:      regionCABundleVolume,
+			MountPath: RegionCABundleDir,
+		})
+	}
+	for i, secretName := range secretNames {
+		res = append(res, corev1.VolumeMount{
+			Name:      fmt.Sprintf("region-ca-bundle-%d", i),
+			MountPath: path.Join(RegionCABundlePath, secretName+".pem"),
+			SubPath:   tls.CABundleKey,
+			ReadOnly:  true,
+		})
+	}
+	return res
+}
@@ -0,0 +1,77 @@
+package horizon
+
+import (
+	"testing"
+
+	horizonv1 "github.com/openstack-k8s-operators/horizon-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/lib-common/modules/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRegionKeystoneURL(t *testing.T) {
+	assert.Equal(t, "https://keystone.r2:5000/v3", GetRegionKeystoneURL("https://keystone.r2:5000"))
+	assert.Equal(t, "https://keystone.r2:5000/v3", GetRegionKeystoneURL("https://keystone.r2:5000/"))
+	assert.Equal(t, "https://keystone.r2:5000/v3", GetRegionKeystoneURL("https://keystone.r2:5000/v3"))
+	assert.Equal(t, "https://keystone.r2:5000/v3", GetRegionKeystoneURL("https://keystone.r2:5000/v3/"))
+}
+
+func TestGetExtraMounts(t *testing.T) {
+	extraMounts := []horizonv1.HorizonExtraVolMounts{
+		{Name: "any", VolMounts: []storage.VolMounts{}},
+		{Name: "one", Region: "regionOne", VolMounts: []storage.VolMounts{}},
+		{Name: "two", Region: "regionTwo", VolMounts: []storage.VolMounts{}},
+	}
+	instance := &horizonv1.Horizon{}
+	instance.Spec.ExtraMounts = extraMounts
+
+	// without regions every extraMount is mounted
+	assert.Equal(t, extraMounts, GetExtraMounts(instance))
+
+	instance.Spec.Regions = []horizonv1.HorizonRegionSpec{
+		{Name: "regionOne"},
+		{Name: "regionThree", KeystoneURL: "https://keystone.r3:5000"},
+	}
+	names := []string{}
+	for _, exv := range GetExtraMounts(instance) {
+		names = append(names, exv.Name)
+	}
+	assert.Equal(t, []string{"any", "one"}, names)
+}
+
+func TestRegionCABundles(t *testing.T) {
+	regions := []horizonv1.HorizonRegionSpec{
+		{Name: "regionOne"},
+		{Name: "regionTwo", KeystoneURL: "https://keystone.r2:5000", CaBundleSecretName: "r2-ca"},
+		{Name: "regionThree", KeystoneURL: "https://keystone.r3:5000", CaBundleSecretName: "common-ca"},
+		{Name: "regionFour", KeystoneURL: "https://keystone.r4:5000", CaBundleSecretName: "r2-ca"},
+	}
+	assert.Equal(t, []string{"common-ca", "r2-ca"}, GetRegionCABundleSecretNames(regions))
+
+	volumes := getRegionCAVolumes(regions)
+	assert.Len(t, volumes, 3)
+	assert.Equal(t, "region-ca-bundle", volumes[0].Name)
+	assert.NotNil(t, volumes[0].EmptyDir)
+	assert.Equal(t, "region-ca-bundle-0", volumes[1].Name)
+	assert.Equal(t, "common-ca", volumes[1].Secret.SecretName)
+
+	mounts := getRegionCAVolumeMounts(regions)
+	assert.Len(t, mounts, 3)
+	assert.Equal(t, "region-ca-bundle", mounts[0].Name)
+	assert.Equal(t, "/run/openstack-dashboard/ca-bundle", mounts[0].MountPath)
+	assert.Equal(t, "region-ca-bundle-1", mounts[2].Name)
+	assert.Equal(t, "/var/lib/config-data/ca-bundles/r2-ca.pem", mounts[2].MountPath)
+	assert.Equal(t, "tls-ca-bundle.pem", mounts[2].SubPath)
+
+	assert.Empty(t, GetRegionCABundleSecretNames(nil))
+	assert.Empty(t, getRegionCAVolumes([]horizonv1.HorizonRegionSpec{{Name: "regionOne"}}))
+}
+
+func TestGetAvailableRegions(t *testing.T) {
+	assert.Equal(t, []string{
+		`("https://keystone.r1:5000/v3", "regionOne")`,
+		`("https://keystone.r2:5000/v3", "two\"), (\"evil")`,
+	}, GetAvailableRegions([]Region{
+		{Name: "regionOne", KeystoneURL: "https://keystone.r1:5000/v3"},
+		{Name: `two"), ("evil`, KeystoneURL: "https://keystone.r2:5000/v3"},
+	}))
+}
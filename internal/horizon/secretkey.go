@@ -0,0 +1,56 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package horizon
+
+import (
+	"time"
+
+	horizonv1 "github.com/openstack-k8s-operators/horizon-operator/api/v1beta1"
+)
+
+const (
+	// SecretKeySelector - key of the horizon Secret holding SECRET_KEY
+	SecretKeySelector = "horizon-secret"
+	// PreviousSecretKeySelector - key of the horizon Secret holding the
+	// SECRET_KEY replaced by the last rotation, used as SECRET_KEY_FALLBACKS
+	PreviousSecretKeySelector = "horizon-secret-previous"
+)
+
+// IsSecretKeyRotationDue - returns true when SECRET_KEY has to be rotated,
+// either because the rotation annotation changed or because the rotation
+// interval elapsed since lastRotated
+func IsSecretKeyRotationDue(instance *horizonv1.Horizon, lastRotated time.Time, now time.Time) bool {
+	if trigger, ok := instance.Annotations[horizonv1.SecretKeyRotationAnnotation]; ok &&
+		trigger != instance.Status.SecretKeyRotationTrigger {
+		return true
+	}
+	if instance.Spec.SecretKeyRotation == nil || instance.Spec.SecretKeyRotation.Interval == nil {
+		return false
+	}
+	return !now.Before(lastRotated.Add(instance.Spec.SecretKeyRotation.Interval.Duration))
+}
+
+// GetSecretKeyRotationRequeue - returns the delay until the next automatic
+// rotation of SECRET_KEY, or 0 when there is none
+func GetSecretKeyRotationRequeue(instance *horizonv1.Horizon, now time.Time) time.Duration {
+	if instance.Spec.SecretKeyRotation == nil || instance.Spec.SecretKeyRotation.Interval == nil ||
+		instance.Status.SecretKeyLastRotated == nil {
+		return 0
+	}
+	next := instance.Status.SecretKeyLastRotated.Add(instance.Spec.SecretKeyRotation.Interval.Duration)
+	// requeue right away when the rotation is overdue
+	return max(next.Sub(now), time.Second)
+}
@@ -0,0 +1,95 @@
+package horizon
+
+import (
+	"testing"
+	"time"
+
+	horizonv1 "github.com/openstack-k8s-operators/horizon-operator/api/v1beta1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestIsSecretKeyRotationDue(t *testing.T) {
+	lastRotated := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	day := &horizonv1.HorizonSecretKeyRotationSpec{
+		Interval: &metav1.Duration{Duration: 24 * time.Hour},
+	}
+
+	testCases := []struct {
+		name        string
+		rotation    *horizonv1.HorizonSecretKeyRotationSpec
+		annotation  *string
+		lastTrigger string
+		now         time.Time
+		expected    bool
+	}{
+		{
+			name:     "No rotation policy",
+			now:      lastRotated.Add(365 * 24 * time.Hour),
+			expected: false,
+		},
+		{
+			name:     "Interval not elapsed",
+			rotation: day,
+			now:      lastRotated.Add(23 * time.Hour),
+			expected: false,
+		},
+		{
+			name:     "Interval elapsed",
+			rotation: day,
+			now:      lastRotated.Add(24 * time.Hour),
+			expected: true,
+		},
+		{
+			name:       "New trigger annotation",
+			annotation: ptr.To("2024-01-01"),
+			now:        lastRotated,
+			expected:   true,
+		},
+		{
+			name:        "Handled trigger annotation",
+			annotation:  ptr.To("2024-01-01"),
+			lastTrigger: "2024-01-01",
+			now:         lastRotated,
+			expected:    false,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := &horizonv1.Horizon{
+				Spec: horizonv1.HorizonSpec{
+					HorizonSpecCore: horizonv1.HorizonSpecCore{
+						SecretKeyRotation: tt.rotation,
+					},
+				},
+				Status: horizonv1.HorizonStatus{
+					SecretKeyRotationTrigger: tt.lastTrigger,
+				},
+			}
+			if tt.annotation != nil {
+				instance.Annotations = map[string]string{
+					horizonv1.SecretKeyRotationAnnotation: *tt.annotation,
+				}
+			}
+			assert.Equal(t, tt.expected, IsSecretKeyRotationDue(instance, lastRotated, tt.now))
+		})
+	}
+}
+
+func TestGetSecretKeyRotationRequeue(t *testing.T) {
+	lastRotated := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	instance := &horizonv1.Horizon{
+		Status: horizonv1.HorizonStatus{
+			SecretKeyLastRotated: &metav1.Time{Time: lastRotated},
+		},
+	}
+	assert.Equal(t, time.Duration(0), GetSecretKeyRotationRequeue(instance, lastRotated))
+
+	instance.Spec.SecretKeyRotation = &horizonv1.HorizonSecretKeyRotationSpec{
+		Interval: &metav1.Duration{Duration: 24 * time.Hour},
+	}
+	assert.Equal(t, 4*time.Hour, GetSecretKeyRotationRequeue(instance, lastRotated.Add(20*time.Hour)))
+	assert.Equal(t, time.Second, GetSecretKeyRotationRequeue(instance, lastRotated.Add(48*time.Hour)))
+}
@@ -0,0 +1,108 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package horizon
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+
+	horizonv1 "github.com/openstack-k8s-operators/horizon-operator/api/v1beta1"
+	redisv1 "github.com/openstack-k8s-operators/infra-operator/apis/redis/v1beta1"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/tls"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/util"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// RedisPortName - name of the port of the Service created by infra-operator
+// for a Redis instance
+const RedisPortName = "redis"
+
+// RedisEndpoint - connection settings of the Redis instance storing the
+// sessions
+type RedisEndpoint struct {
+	// URL - LOCATION of the redis cache
+	URL string
+	// CACert - CA bundle verifying the certificate of Redis, empty when TLS
+	// is disabled
+	CACert string
+}
+
+// sessionKeyInputs - inputs invalidating the user sessions with the stable
+// strategy. SECRET_KEY is not one of them: the key replaced by a rotation is
+// kept in SECRET_KEY_FALLBACKS, so the sessions survive it
+type sessionKeyInputs struct {
+	Name                       string
+	KeystoneURL                string
+	SSO                        *horizonv1.HorizonSSOSpec
+	KeystoneMultiDomainSupport *bool
+	KeystoneDefaultDomain      string
+}
+
+// GetSessionKeyPrefix - returns the KEY_PREFIX of the cached sessions. It is
+// empty with the configHash strategy, the CONFIG_HASH env var being used
+// instead
+func GetSessionKeyPrefix(
+	instance *horizonv1.Horizon,
+	keystoneURL string,
+) (string, error) {
+	sk := instance.Spec.SessionKey
+	if sk == nil {
+		return "", nil
+	}
+
+	switch sk.Strategy {
+	case horizonv1.SessionKeyStable:
+		inputs := sessionKeyInputs{
+			Name:        instance.Name,
+			KeystoneURL: keystoneURL,
+			SSO:         instance.Spec.SSO,
+		}
+		if st := instance.Spec.Settings; st != nil {
+			inputs.KeystoneMultiDomainSupport = st.KeystoneMultiDomainSupport
+			inputs.KeystoneDefaultDomain = st.KeystoneDefaultDomain
+		}
+		return util.ObjectHash(inputs)
+	case horizonv1.SessionKeyGeneration:
+		return fmt.Sprintf("%s-%d", instance.Name, sk.Generation), nil
+	default:
+		return "", nil
+	}
+}
+
+// GetRedisEndpoint - returns the connection settings of the Redis instance
+// storing the sessions. The Redis status doesn't report an endpoint, the host
+// and the port are read from the Service infra-operator creates for the
+// instance. With TLS the certificate of Redis is verified with the CA bundle
+// mounted from tls.caBundleSecretName
+func GetRedisEndpoint(redis *redisv1.Redis, svc *corev1.Service) RedisEndpoint {
+	port := int32(0)
+	for _, p := range svc.Spec.Ports {
+		if p.Name == RedisPortName || port == 0 {
+			port = p.Port
+		}
+	}
+	host := net.JoinHostPort(
+		fmt.Sprintf("%s.%s.svc", svc.Name, svc.Namespace), strconv.Itoa(int(port)))
+
+	if !redis.Spec.TLS.Enabled() {
+		return RedisEndpoint{URL: "redis://" + host}
+	}
+	return RedisEndpoint{
+		URL:    "rediss://" + host,
+		CACert: tls.DownstreamTLSCABundlePath,
+	}
+}
@@ -0,0 +1,91 @@
+package horizon
+
+import (
+	"testing"
+
+	horizonv1 "github.com/openstack-k8s-operators/horizon-operator/api/v1beta1"
+	redisv1 "github.com/openstack-k8s-operators/infra-operator/apis/redis/v1beta1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestGetSessionKeyPrefix(t *testing.T) {
+
+	newInstance := func(sk *horizonv1.HorizonSessionKeySpec, settings *horizonv1.HorizonSettings) *horizonv1.Horizon {
+		return &horizonv1.Horizon{
+			ObjectMeta: metav1.ObjectMeta{Name: "horizon"},
+			Spec: horizonv1.HorizonSpec{
+				HorizonSpecCore: horizonv1.HorizonSpecCore{
+					SessionKey: sk,
+					Settings:   settings,
+				},
+			},
+		}
+	}
+	stable := &horizonv1.HorizonSessionKeySpec{Strategy: horizonv1.SessionKeyStable}
+
+	t.Run("ConfigHash", func(t *testing.T) {
+		prefix, err := GetSessionKeyPrefix(newInstance(nil, nil), "http://keystone")
+		assert.NoError(t, err)
+		assert.Empty(t, prefix)
+	})
+
+	t.Run("Generation", func(t *testing.T) {
+		prefix, err := GetSessionKeyPrefix(newInstance(&horizonv1.HorizonSessionKeySpec{
+			Strategy:   horizonv1.SessionKeyGeneration,
+			Generation: 3,
+		}, nil), "http://keystone")
+		assert.NoError(t, err)
+		assert.Equal(t, "horizon-3", prefix)
+	})
+
+	t.Run("Stable", func(t *testing.T) {
+		prefix, err := GetSessionKeyPrefix(newInstance(stable, nil), "http://keystone")
+		assert.NoError(t, err)
+		assert.NotEmpty(t, prefix)
+
+		// unrelated settings don't change the prefix
+		same, err := GetSessionKeyPrefix(newInstance(stable, &horizonv1.HorizonSettings{
+			TimeZone: "Europe/Rome",
+		}), "http://keystone")
+		assert.NoError(t, err)
+		assert.Equal(t, prefix, same)
+
+		// keystone URL and domain settings do
+		other, err := GetSessionKeyPrefix(newInstance(stable, &horizonv1.HorizonSettings{
+			KeystoneDefaultDomain: "other",
+		}), "http://keystone")
+		assert.NoError(t, err)
+		assert.NotEqual(t, prefix, other)
+
+		other, err = GetSessionKeyPrefix(newInstance(stable, nil), "https://keystone")
+		assert.NoError(t, err)
+		assert.NotEqual(t, prefix, other)
+	})
+}
+
+func TestGetRedisEndpoint(t *testing.T) {
+	redis := &redisv1.Redis{}
+	redis.Name = "redis"
+	redis.Namespace = "openstack"
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "redis", Namespace: "openstack"},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{Name: "sentinel", Port: 26379},
+				{Name: RedisPortName, Port: 6380},
+			},
+		},
+	}
+
+	assert.Equal(t, RedisEndpoint{URL: "redis://redis.openstack.svc:6380"}, GetRedisEndpoint(redis, svc))
+
+	// the certificate of Redis is verified with the CA bundle of the pods
+	redis.Spec.TLS.SecretName = ptr.To("redis-cert")
+	assert.Equal(t, RedisEndpoint{
+		URL:    "rediss://redis.openstack.svc:6380",
+		CACert: "/etc/pki/ca-trust/extracted/pem/tls-ca-bundle.pem",
+	}, GetRedisEndpoint(redis, svc))
+}
@@ -0,0 +1,74 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package horizon
+
+import (
+	"fmt"
+	"strconv"
+
+	horizonv1 "github.com/openstack-k8s-operators/horizon-operator/api/v1beta1"
+)
+
+// GetSettings - returns the dashboard settings defined in the Horizon spec,
+// indexed by the Django setting name and formatted as Python literals, so
+// they can be rendered as-is in the SettingsFileName snippet
+func GetSettings(settings *horizonv1.HorizonSettings) map[string]string {
+
+	res := map[string]string{}
+	if settings == nil {
+		return res
+	}
+
+	if settings.SessionTimeout != nil {
+		res["SESSION_TIMEOUT"] = fmt.Sprintf("%d", *settings.SessionTimeout)
+	}
+	if pv := settings.PasswordValidator; pv != nil {
+		res[`HORIZON_CONFIG["password_validator"]`] = fmt.Sprintf(
+			"{\"regex\": %s, \"help_text\": _(%s)}", pythonString(pv.Regex), pythonString(pv.HelpText))
+	}
+	if settings.ImagesUploadMode != "" {
+		res["HORIZON_IMAGES_UPLOAD_MODE"] = pythonString(settings.ImagesUploadMode)
+	}
+	if settings.TimeZone != "" {
+		res["TIME_ZONE"] = pythonString(settings.TimeZone)
+	}
+	if settings.DefaultTheme != "" {
+		res["DEFAULT_THEME"] = pythonString(settings.DefaultTheme)
+	}
+	if settings.KeystoneMultiDomainSupport != nil {
+		res["OPENSTACK_KEYSTONE_MULTIDOMAIN_SUPPORT"] = pythonBool(*settings.KeystoneMultiDomainSupport)
+	}
+	if settings.KeystoneDefaultDomain != "" {
+		res["OPENSTACK_KEYSTONE_DEFAULT_DOMAIN"] = pythonString(settings.KeystoneDefaultDomain)
+	}
+	if settings.ConsoleType != "" {
+		res["CONSOLE_TYPE"] = pythonString(settings.ConsoleType)
+	}
+	return res
+}
+
+// pythonString - returns s as a double quoted Python string literal
+func pythonString(s string) string {
+	return strconv.Quote(s)
+}
+
+// pythonBool - returns b as a Python boolean literal
+func pythonBool(b bool) string {
+	if b {
+		return "True"
+	}
+	return "False"
+}
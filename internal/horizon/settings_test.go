@@ -0,0 +1,57 @@
+package horizon
+
+import (
+	"testing"
+
+	horizonv1 "github.com/openstack-k8s-operators/horizon-operator/api/v1beta1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/utils/ptr"
+)
+
+func TestGetSettings(t *testing.T) {
+
+	testCases := []struct {
+		name     string
+		settings *horizonv1.HorizonSettings
+		expected map[string]string
+	}{
+		{
+			name:     "No settings",
+			settings: nil,
+			expected: map[string]string{},
+		},
+		{
+			name: "All settings",
+			settings: &horizonv1.HorizonSettings{
+				SessionTimeout: ptr.To[int32](3600),
+				PasswordValidator: &horizonv1.HorizonPasswordValidator{
+					Regex:    `^(?=.*\d).{8,}$`,
+					HelpText: "Use at least 8 \"chars\"",
+				},
+				ImagesUploadMode:           "legacy",
+				TimeZone:                   "Europe/Rome",
+				DefaultTheme:               "material",
+				KeystoneMultiDomainSupport: ptr.To(false),
+				KeystoneDefaultDomain:      "Default",
+				ConsoleType:                "SPICE",
+			},
+			expected: map[string]string{
+				"SESSION_TIMEOUT": "3600",
+				`HORIZON_CONFIG["password_validator"]`: `{"regex": "^(?=.*\\d).{8,}$", ` +
+					`"help_text": _("Use at least 8 \"chars\"")}`,
+				"HORIZON_IMAGES_UPLOAD_MODE":             `"legacy"`,
+				"TIME_ZONE":                              `"Europe/Rome"`,
+				"DEFAULT_THEME":                          `"material"`,
+				"OPENSTACK_KEYSTONE_MULTIDOMAIN_SUPPORT": "False",
+				"OPENSTACK_KEYSTONE_DEFAULT_DOMAIN":      `"Default"`,
+				"CONSOLE_TYPE":                           `"SPICE"`,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, GetSettings(tc.settings))
+		})
+	}
+}
@@ -0,0 +1,51 @@
+/*
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package horizon
+
+import (
+	"fmt"
+
+	horizonv1 "github.com/openstack-k8s-operators/horizon-operator/api/v1beta1"
+)
+
+// WebSSO - WebSSO settings rendered in local_settings.py, formatted as
+// Python literals so the values of the spec can't break out of their string
+type WebSSO struct {
+	// Choices - entries of WEBSSO_CHOICES
+	Choices []string
+	// IDPMapping - entries of WEBSSO_IDP_MAPPING
+	IDPMapping []string
+	// InitialChoice - WEBSSO_INITIAL_CHOICE
+	InitialChoice string
+}
+
+// GetWebSSO - returns the WebSSO settings of the login choices defined in the
+// sso section, the Keystone credentials choice comes first
+func GetWebSSO(sso *horizonv1.HorizonSSOSpec) *WebSSO {
+	res := &WebSSO{
+		Choices: []string{fmt.Sprintf("(%s, _(%s))",
+			pythonString(horizonv1.SSOCredentialsChoice), pythonString(sso.CredentialsDisplayName))},
+		IDPMapping:    []string{},
+		InitialChoice: pythonString(sso.InitialChoice),
+	}
+	for _, idp := range sso.IdentityProviders {
+		res.Choices = append(res.Choices, fmt.Sprintf("(%s, _(%s))",
+			pythonString(idp.Name), pythonString(idp.DisplayName)))
+		res.IDPMapping = append(res.IDPMapping, fmt.Sprintf("%s: (%s, %s)",
+			pythonString(idp.Name), pythonString(idp.IdentityProvider), pythonString(idp.Protocol)))
+	}
+	return res
+}
@@ -0,0 +1,42 @@
+package horizon
+
+import (
+	"testing"
+
+	horizonv1 "github.com/openstack-k8s-operators/horizon-operator/api/v1beta1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetWebSSO(t *testing.T) {
+	sso := &horizonv1.HorizonSSOSpec{
+		InitialChoice:          "okta",
+		CredentialsDisplayName: "Keystone Credentials",
+		IdentityProviders: []horizonv1.HorizonSSOIdentityProvider{
+			{
+				Name:             "okta",
+				DisplayName:      "Okta",
+				IdentityProvider: "okta-idp",
+				Protocol:         "openid",
+			},
+			{
+				Name:             "adfs",
+				DisplayName:      `ADFS"), ("evil`,
+				IdentityProvider: "adfs-idp",
+				Protocol:         "saml2",
+			},
+		},
+	}
+
+	assert.Equal(t, &WebSSO{
+		Choices: []string{
+			`("credentials", _("Keystone Credentials"))`,
+			`("okta", _("Okta"))`,
+			`("adfs", _("ADFS\"), (\"evil"))`,
+		},
+		IDPMapping: []string{
+			`"okta": ("okta-idp", "openid")`,
+			`"adfs": ("adfs-idp", "saml2")`,
+		},
+		InitialChoice: `"okta"`,
+	}, GetWebSSO(sso))
+}
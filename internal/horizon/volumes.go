@@ -45,14 +45,14 @@ func getVolumes(
 			Name: "horizon-secret-key",
 			VolumeSource: corev1.VolumeSource{
 				Secret: &corev1.SecretVolumeSource{
-					SecretName:  ServiceName,
+					SecretName:  name,
 					DefaultMode: &config0600AccessMode,
 				},
 			},
 		},
 	}
 	// append scripts volume
-	res = append(res, getScriptVolume())
+	res = append(res, getScriptVolume(name))
 	for _, exv := range extraVol {
 		for _, vol := range exv.Propagate(svc) {
 			for _, v := range vol.Volumes {
@@ -101,6 +101,21 @@ func getVolumeMounts(
 	return vm
 }
 
+// getDBSyncVolumeMounts - VolumeMounts of the db sync Job, which uses
+// horizon-dbsync.json as kolla config
+func getDBSyncVolumeMounts(
+	extraVol []horizonv1.HorizonExtraVolMounts,
+	svc []storage.PropagationType,
+) []corev1.VolumeMount {
+	vm := getVolumeMounts(extraVol, svc)
+	for i := range vm {
+		if vm[i].SubPath == "horizon.json" {
+			vm[i].SubPath = "horizon-dbsync.json"
+		}
+	}
+	return vm
+}
+
 // getScriptVolumeMount -
 func getScriptVolumeMount() []corev1.VolumeMount {
 	return []corev1.VolumeMount{
@@ -119,7 +134,7 @@ func getScriptVolumeMount() []corev1.VolumeMount {
 }
 
 // getScriptVolume -
-func getScriptVolume() corev1.Volume {
+func getScriptVolume(name string) corev1.Volume {
 	var scriptsVolumeDefaultMode int32 = 0755
 	return corev1.Volume{
 		Name: "scripts",
@@ -127,7 +142,7 @@ func getScriptVolume() corev1.Volume {
 			ConfigMap: &corev1.ConfigMapVolumeSource{
 				DefaultMode: &scriptsVolumeDefaultMode,
 				LocalObjectReference: corev1.LocalObjectReference{
-					Name: ServiceName + "-scripts",
+					Name: name + "-scripts",
 				},
 			},
 		},
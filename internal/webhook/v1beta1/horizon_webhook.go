@@ -22,8 +22,14 @@ import (
 	"errors"
 	"fmt"
 
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -43,7 +49,7 @@ var horizonlog = logf.Log.WithName("horizon-resource")
 // SetupHorizonWebhookWithManager registers the webhook for Horizon in the manager.
 func SetupHorizonWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr).For(&horizonv1beta1.Horizon{}).
-		WithValidator(&HorizonCustomValidator{}).
+		WithValidator(&HorizonCustomValidator{Client: mgr.GetAPIReader()}).
 		WithDefaulter(&HorizonCustomDefaulter{}).
 		Complete()
 }
@@ -87,13 +93,15 @@ func (d *HorizonCustomDefaulter) Default(_ context.Context, obj runtime.Object)
 // NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
 // as this struct is used only for temporary operations and does not need to be deeply copied.
 type HorizonCustomValidator struct {
-	// TODO(user): Add more fields as needed for validation
+	// Client reads the Secret holding the SECRET_KEY supplied by the user
+	// and the other Horizon instances of the namespace
+	Client client.Reader
 }
 
 var _ webhook.CustomValidator = &HorizonCustomValidator{}
 
 // ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type Horizon.
-func (v *HorizonCustomValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+func (v *HorizonCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
 	horizon, ok := obj.(*horizonv1beta1.Horizon)
 	if !ok {
 		return nil, fmt.Errorf("expected a Horizon object but got %T: %w", obj, ErrInvalidObjectType)
@@ -101,11 +109,18 @@ func (v *HorizonCustomValidator) ValidateCreate(_ context.Context, obj runtime.O
 	horizonlog.Info("Validation for Horizon upon creation", "name", horizon.GetName())
 
 	// Call the ValidateCreate method on the Horizon type
-	return horizon.ValidateCreate()
+	warns, err := horizon.ValidateCreate()
+	if err != nil {
+		return warns, err
+	}
+	if err := v.validateSecretKey(ctx, horizon); err != nil {
+		return warns, err
+	}
+	return warns, v.validateRole(ctx, horizon)
 }
 
 // ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type Horizon.
-func (v *HorizonCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+func (v *HorizonCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
 	horizon, ok := newObj.(*horizonv1beta1.Horizon)
 	if !ok {
 		return nil, fmt.Errorf("expected a Horizon object for the newObj but got %T: %w", newObj, ErrInvalidObjectType)
@@ -113,7 +128,14 @@ func (v *HorizonCustomValidator) ValidateUpdate(_ context.Context, oldObj, newOb
 	horizonlog.Info("Validation for Horizon upon update", "name", horizon.GetName())
 
 	// Call the ValidateUpdate method on the Horizon type
-	return horizon.ValidateUpdate(oldObj)
+	warns, err := horizon.ValidateUpdate(oldObj)
+	if err != nil {
+		return warns, err
+	}
+	if err := v.validateSecretKey(ctx, horizon); err != nil {
+		return warns, err
+	}
+	return warns, v.validateRole(ctx, horizon)
 }
 
 // ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type Horizon.
@@ -127,3 +149,61 @@ func (v *HorizonCustomValidator) ValidateDelete(_ context.Context, obj runtime.O
 	// Call the ValidateDelete method on the Horizon type
 	return horizon.ValidateDelete()
 }
+
+// validateSecretKey checks the SECRET_KEY supplied through spec.secret when
+// the Secret already exists and holds it. The controller checks it again when
+// the Secret is created or changed later
+func (v *HorizonCustomValidator) validateSecretKey(ctx context.Context, horizon *horizonv1beta1.Horizon) error {
+	if v.Client == nil {
+		return nil
+	}
+	secret := &corev1.Secret{}
+	err := v.Client.Get(ctx, types.NamespacedName{Name: horizon.Spec.Secret, Namespace: horizon.Namespace}, secret)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			horizonlog.Error(err, "unable to read the SECRET_KEY Secret", "name", horizon.GetName())
+		}
+		return nil
+	}
+	key, ok := secret.Data[horizon.Spec.PasswordSelectors.SecretKey]
+	if !ok {
+		return nil
+	}
+	if err := horizonv1beta1.ValidateSecretKey(string(key)); err != nil {
+		return apierrors.NewInvalid(
+			schema.GroupKind{Group: "horizon.openstack.org", Kind: "Horizon"},
+			horizon.Name, field.ErrorList{field.Invalid(
+				field.NewPath("spec").Child("passwordSelectors", "secretKey"),
+				horizon.Spec.PasswordSelectors.SecretKey,
+				err.Error())})
+	}
+	return nil
+}
+
+// validateRole makes sure a single Horizon instance per namespace carries the
+// primary role label, so consumers resolving it get an unambiguous answer.
+// The check is racy, GetHorizon resolves the ties of concurrent creations
+func (v *HorizonCustomValidator) validateRole(ctx context.Context, horizon *horizonv1beta1.Horizon) error {
+	if v.Client == nil || horizon.Labels[horizonv1beta1.HorizonRoleLabel] != horizonv1beta1.HorizonRolePrimary {
+		return nil
+	}
+	horizonList := &horizonv1beta1.HorizonList{}
+	err := v.Client.List(ctx, horizonList,
+		client.InNamespace(horizon.Namespace),
+		client.MatchingLabels(horizonv1beta1.HorizonRoleSelector(horizonv1beta1.HorizonRolePrimary)))
+	if err != nil {
+		return fmt.Errorf("unable to list the primary Horizon instances of namespace %s: %w", horizon.Namespace, err)
+	}
+	for _, other := range horizonList.Items {
+		if other.Name == horizon.Name {
+			continue
+		}
+		return apierrors.NewInvalid(
+			schema.GroupKind{Group: "horizon.openstack.org", Kind: "Horizon"},
+			horizon.Name, field.ErrorList{field.Invalid(
+				field.NewPath("metadata").Child("labels").Key(horizonv1beta1.HorizonRoleLabel),
+				horizonv1beta1.HorizonRolePrimary,
+				fmt.Sprintf("Horizon %s is already the primary dashboard of namespace %s", other.Name, horizon.Namespace))})
+	}
+	return nil
+}
@@ -0,0 +1,20 @@
+# -*- coding: utf-8 -*-
+
+# Settings rendered by the horizon-operator from the Horizon spec.settings
+# section. 9999_custom_settings.py (customServiceConfig) is loaded afterwards
+# and can override any of them.
+
+from django.utils.translation import gettext_lazy as _
+{{ range $key, $value := .settings }}
+{{ $key }} = {{ $value }}
+{{- end }}
+{{- if (index . "policyFiles") }}
+
+# Policy files of the OpenStack services (spec.policies), copied to
+# POLICY_FILES_PATH
+POLICY_FILES.update({
+{{- range .policyFiles }}
+    "{{ .Service }}": "{{ .FileName }}",
+{{- end }}
+})
+{{- end }}
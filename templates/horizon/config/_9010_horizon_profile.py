@@ -0,0 +1,10 @@
+# -*- coding: utf-8 -*-
+
+# Pluggable dashboard settings rendered by the horizon-operator from the
+# Horizon spec.profile. The file is only placed in the pod when the profile
+# hides a dashboard.
+{{- if .disabledDashboard }}
+
+DASHBOARD = '{{ .disabledDashboard }}'
+DISABLED = True
+{{- end }}
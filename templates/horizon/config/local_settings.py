@@ -22,12 +22,15 @@ from horizon.utils import secret_key
 from openstack_dashboard import exceptions
 from openstack_dashboard.settings import HORIZON_CONFIG
 
-WEBROOT = '/dashboard/'
-LOGIN_URL = '/dashboard/auth/login/'
-LOGOUT_URL = '/dashboard/auth/logout/'
-LOGIN_REDIRECT_URL = '/dashboard/'
+WEBROOT = '{{ .webRoot }}/'
+LOGIN_URL = '{{ .webRoot }}/auth/login/'
+LOGOUT_URL = '{{ .webRoot }}/auth/logout/'
+LOGIN_REDIRECT_URL = '{{ .webRoot }}/'
 SECURE_PROXY_SSL_HEADER = ('HTTP_X_FORWARDED_PROTO', 'https')
-OPENSTACK_ENDPOINT_TYPE = "publicURL"
+OPENSTACK_ENDPOINT_TYPE = "{{ .endpointType }}"
+{{- if .secondaryEndpointType }}
+SECONDARY_ENDPOINT_TYPE = "{{ .secondaryEndpointType }}"
+{{- end }}
 OPENSTACK_API_VERSIONS = {
   'identity': 3,
 }
@@ -56,35 +59,33 @@ DEBUG = False
 # For more information see:
 # https://docs.djangoproject.com/en/dev/ref/settings/#allowed-hosts
 
-# get_pod_ip retrieves the pod's primary interface IP address. This is necessary
-# due to the dynamic IP addressing of pods. The HealthCheck needs to be able to
-# check the specific pod. We can't simply check via the route, since such a check
-# could land on any of the replicas. Instead, we need to explicity check the pod
-# we're currently running on. Therefore, we need to execute this function to
-# retrieve the IP address, which we will then in turn add to the ALLOWED_HOSTS list.
-def get_pod_ip():
-    import socket
-    s = socket.socket(socket.AF_INET, socket.SOCK_DGRAM)
-    hostport = (
-        "{{ .horizonEndpointHost }}",
-        {{- if .isPublicHTTPS }}
-        443
-        {{- else }}
-        80
-        {{- end }}
-    )
-    try:
-        s.connect(hostport)
-        return s.getsockname()[0]
-    except socket.gaierror:
-        s.close()
-        s = socket.socket(socket.AF_INET6, socket.SOCK_DGRAM)
-        s.connect(hostport)
-        return "[{}]".format(s.getsockname()[0])
-    finally:
-        s.close()
+# get_pod_ips returns the IP addresses of the pod, injected by the downward API
+# in POD_IPS (status.podIPs, both families on a dual-stack cluster) and POD_IP.
+# The HealthCheck needs to be able to check the specific pod. We can't simply
+# check via the route, since such a check could land on any of the replicas.
+# Instead, we need to explicitly check the pod we're currently running on.
+# Therefore, we add its addresses to the ALLOWED_HOSTS list, IPv6 addresses
+# between brackets as they appear in the Host header.
+def get_pod_ips():
+    ips = os.environ.get("POD_IPS") or os.environ.get("POD_IP", "")
+    return [
+        "[{}]".format(ip) if ":" in ip else ip
+        for ip in ips.split(",") if ip
+    ]
 
-ALLOWED_HOSTS = [get_pod_ip(), "{{ .horizonEndpointHost }}"]
+ALLOWED_HOSTS = get_pod_ips() + [
+{{- range .allowedHosts }}
+    "{{ . }}",
+{{- end }}
+]
+
+# Origins of the unsafe (e.g. POST) requests trusted by the CSRF protection,
+# required for the additional host names of the dashboard
+CSRF_TRUSTED_ORIGINS = [
+{{- range .csrfTrustedOrigins }}
+    "{{ . }}",
+{{- end }}
+]
 
 USE_X_FORWARDED_HOST = True
 
@@ -114,12 +115,22 @@ LOCAL_PATH = os.path.dirname(os.path.abspath(__file__))
 SECRET_KEY = secret_key.read_from_file(
     key_file='/etc/openstack-dashboard/.horizon-secret'
 )
+{{- if .secretKeyFallback }}
+# The key replaced by the last rotation, to keep the existing sessions and CSRF
+# tokens valid
+SECRET_KEY_FALLBACKS = [
+    secret_key.read_from_file(
+        key_file='/etc/openstack-dashboard/.horizon-secret-previous'
+    )
+]
+{{- end }}
 
 # We recommend you use memcached for development; otherwise after every reload
 # of the django development server, you will have to login again. To use
 # memcached set CACHES to something like below.
 # For more information, see
 # https://docs.djangoproject.com/en/1.11/topics/http/sessions/.
+{{- if eq .sessionBackend "memcached" }}
 {{- if (index . "memcachedAuthCert") }}
 # mtls_context is a helper function returning the tls_context needed for mtls auth
 # against memcached if this is enabled
@@ -132,8 +143,13 @@ CACHES = {
     'default': {
         'BACKEND': 'django.core.cache.backends.memcached.PyMemcacheCache',
         'LOCATION': [ {{.memcachedServers}} ],
+{{- if .sessionKeyPrefix }}
+        # To drop the cached sessions according to spec.sessionKey
+        'KEY_PREFIX': '{{ .sessionKeyPrefix }}',
+{{- else }}
         # To drop the cached sessions when config changes
         'KEY_PREFIX': os.environ['CONFIG_HASH'],
+{{- end }}
         'OPTIONS': {
             "no_delay": True,
             "ignore_exc": True,
@@ -149,12 +165,55 @@ CACHES = {
         }
     },
 }
+{{- else if eq .sessionBackend "redis" }}
+CACHES = {
+    'default': {
+        'BACKEND': 'django.core.cache.backends.redis.RedisCache',
+        'LOCATION': '{{ .redisURL }}',
+{{- if .redisCACert }}
+        # The options are passed to the redis-py connection pool
+        'OPTIONS': {
+            'ssl_ca_certs': '{{ .redisCACert }}',
+        },
+{{- end }}
+{{- if .sessionKeyPrefix }}
+        # To drop the cached sessions according to spec.sessionKey
+        'KEY_PREFIX': '{{ .sessionKeyPrefix }}',
+{{- else }}
+        # To drop the cached sessions when config changes
+        'KEY_PREFIX': os.environ['CONFIG_HASH'],
+{{- end }}
+    },
+}
+{{- else if eq .sessionBackend "database" }}
+# The sessions are stored in the MariaDBDatabase managed by the operator, the
+# tables are created by the db sync Job
+DATABASES = {
+    'default': {
+        'ENGINE': 'django.db.backends.mysql',
+        'HOST': '{{ .databaseHost }}',
+        'NAME': '{{ .databaseName }}',
+        'USER': '{{ .databaseUser }}',
+        'PASSWORD': os.environ['DB_PASSWORD'],
+        # TLS of the connection to Galera, rendered by mariadb-operator
+        'OPTIONS': {
+            'read_default_file': '/etc/my.cnf',
+        },
+    },
+}
+{{- end }}
 
 # If you use ``tox -e runserver`` for developments,then configure
 # SESSION_ENGINE to django.contrib.sessions.backends.signed_cookies
 # as shown below:
+{{- if eq .sessionBackend "signed_cookies" }}
+SESSION_ENGINE = 'django.contrib.sessions.backends.signed_cookies'
+{{- else if eq .sessionBackend "database" }}
+SESSION_ENGINE = 'django.contrib.sessions.backends.db'
+{{- else }}
 #SESSION_ENGINE = 'django.contrib.sessions.backends.signed_cookies'
 SESSION_ENGINE = 'django.contrib.sessions.backends.cache'
+{{- end }}
 
 
 # Send email to the console by default
@@ -172,6 +231,35 @@ OPENSTACK_HOST = "127.0.0.1"
 #OPENSTACK_KEYSTONE_URL = "http://%s/identity/v3" % OPENSTACK_HOST
 
 OPENSTACK_KEYSTONE_URL = "{{ .keystoneURL }}/v3"
+{{- if (index . "regions") }}
+
+# Regions displayed in the region selector of the login page
+AVAILABLE_REGIONS = [
+{{- range .regions }}
+    {{ . }},
+{{- end }}
+]
+{{- end }}
+{{- if (index . "regionCABundle") }}
+OPENSTACK_SSL_CACERT = "{{ .regionCABundle }}"
+{{- end }}
+{{- if (index . "sso") }}
+
+# WebSSO (Keystone federation) login choices
+WEBSSO_ENABLED = True
+WEBSSO_KEYSTONE_URL = "{{ .keystonePublicURL }}/v3"
+WEBSSO_CHOICES = (
+{{- range .sso.Choices }}
+    {{ . }},
+{{- end }}
+)
+WEBSSO_IDP_MAPPING = {
+{{- range .sso.IDPMapping }}
+    {{ . }},
+{{- end }}
+}
+WEBSSO_INITIAL_CHOICE = {{ .sso.InitialChoice }}
+{{- end }}
 
 # The timezone of the server. This should correspond with the timezone
 # of your entire OpenStack installation, and hopefully be in UTC.
@@ -43,6 +43,21 @@ func CreateHorizon(name types.NamespacedName, spec map[string]any) client.Object
 	return th.CreateUnstructured(raw)
 }
 
+func CreateHorizonWithLabels(name types.NamespacedName, labels map[string]any, spec map[string]any) client.Object {
+
+	raw := map[string]any{
+		"apiVersion": "horizon.openstack.org/v1beta1",
+		"kind":       "Horizon",
+		"metadata": map[string]any{
+			"name":      name.Name,
+			"namespace": name.Namespace,
+			"labels":    labels,
+		},
+		"spec": spec,
+	}
+	return th.CreateUnstructured(raw)
+}
+
 func GetDefaultHorizonSpec() map[string]any {
 	return map[string]any{
 		"secret":            SecretName,
@@ -155,3 +170,56 @@ func GetExtraMounts(hemName string, hemPath string) []map[string]any {
 		},
 	}
 }
+
+// GetSSOHorizonSpec - returns a Horizon spec with an OpenID Connect identity
+// provider whose client credentials are stored in the given Secret
+func GetSSOHorizonSpec(credentialsSecret string) map[string]any {
+	spec := GetDefaultHorizonSpec()
+	spec["sso"] = map[string]any{
+		"initialChoice": "okta",
+		"identityProviders": []map[string]any{
+			{
+				"name":                "okta",
+				"displayName":         "Okta",
+				"identityProvider":    "okta-idp",
+				"protocol":            "openid",
+				"providerMetadataURL": "https://okta.example.com/.well-known/openid-configuration",
+				"clientID":            "horizon",
+				"credentialsSecret":   credentialsSecret,
+			},
+			{
+				"name":             "adfs",
+				"identityProvider": "adfs-idp",
+				"protocol":         "saml2",
+			},
+		},
+	}
+	return spec
+}
+
+// CreateSSOCredentialsSecret - creates the Secret holding the OpenID Connect
+// client credentials
+func CreateSSOCredentialsSecret(namespace string, name string) *corev1.Secret {
+	return th.CreateSecret(
+		types.NamespacedName{Namespace: namespace, Name: name},
+		map[string][]byte{
+			horizonv1.SSOClientSecretSelector:     []byte("client-secret"),
+			horizonv1.SSOCryptoPassphraseSelector: []byte("passphrase"),
+		},
+	)
+}
+
+// CreatePolicyConfigMap - creates a ConfigMap holding a service policy file
+func CreatePolicyConfigMap(namespace string, name string, policy string) *corev1.ConfigMap {
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Data: map[string]string{
+			"policy.yaml": policy,
+		},
+	}
+	Expect(k8sClient.Create(ctx, cm)).Should(Succeed())
+	return cm
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package functional_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2" //revive:disable:dot-imports
+	. "github.com/onsi/gomega"    //revive:disable:dot-imports
+
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	horizonv1 "github.com/openstack-k8s-operators/horizon-operator/api/v1beta1"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/helper"
+)
+
+var _ = Describe("Horizon consumer helpers", func() {
+
+	var primaryName types.NamespacedName
+	var secondaryName types.NamespacedName
+	var h *helper.Helper
+
+	BeforeEach(func() {
+		primaryName = types.NamespacedName{
+			Name:      "dashboard",
+			Namespace: namespace,
+		}
+		secondaryName = types.NamespacedName{
+			Name:      "dashboard-admin",
+			Namespace: namespace,
+		}
+
+		kclient, err := kubernetes.NewForConfig(cfg)
+		Expect(err).ToNot(HaveOccurred())
+		h, err = helper.NewHelper(&horizonv1.Horizon{}, k8sClient, kclient, scheme.Scheme, logger)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	When("a single Horizon is deployed", func() {
+		BeforeEach(func() {
+			DeferCleanup(th.DeleteInstance, CreateHorizon(secondaryName, GetDefaultHorizonSpec()))
+		})
+
+		It("is returned by GetHorizon", func() {
+			instance, err := horizonv1.GetHorizon(ctx, h, namespace)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(instance.Name).To(Equal(secondaryName.Name))
+		})
+
+		It("is returned by GetHorizonByName", func() {
+			instance, err := horizonv1.GetHorizonByName(ctx, h, secondaryName.Name, namespace)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(instance.Name).To(Equal(secondaryName.Name))
+
+			_, err = horizonv1.GetHorizonByName(ctx, h, "missing", namespace)
+			Expect(k8s_errors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("is not returned by GetHorizonBySelector without the role label", func() {
+			_, err := horizonv1.GetHorizonBySelector(ctx, h, namespace,
+				horizonv1.HorizonRoleSelector(horizonv1.HorizonRolePrimary))
+			Expect(k8s_errors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	When("several Horizons are deployed without a primary one", func() {
+		BeforeEach(func() {
+			DeferCleanup(th.DeleteInstance, CreateHorizon(primaryName, GetDefaultHorizonSpec()))
+			DeferCleanup(th.DeleteInstance, CreateHorizon(secondaryName, GetDefaultHorizonSpec()))
+		})
+
+		It("fails in GetHorizon", func() {
+			_, err := horizonv1.GetHorizon(ctx, h, namespace)
+			Expect(errors.Is(err, horizonv1.ErrMultipleHorizonsFound)).To(BeTrue())
+		})
+	})
+
+	When("a primary and a secondary Horizon are deployed", func() {
+		BeforeEach(func() {
+			DeferCleanup(th.DeleteInstance, CreateHorizonWithLabels(primaryName,
+				map[string]any{horizonv1.HorizonRoleLabel: horizonv1.HorizonRolePrimary},
+				GetDefaultHorizonSpec()))
+			DeferCleanup(th.DeleteInstance, CreateHorizon(secondaryName, GetDefaultHorizonSpec()))
+		})
+
+		It("resolves the primary dashboard", func() {
+			instance, err := horizonv1.GetHorizonBySelector(ctx, h, namespace,
+				horizonv1.HorizonRoleSelector(horizonv1.HorizonRolePrimary))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(instance.Name).To(Equal(primaryName.Name))
+
+			instance, err = horizonv1.GetHorizon(ctx, h, namespace)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(instance.Name).To(Equal(primaryName.Name))
+		})
+
+		It("fails when the selector matches several instances", func() {
+			_, err := horizonv1.GetHorizonBySelector(ctx, h, namespace, map[string]string{})
+			Expect(errors.Is(err, horizonv1.ErrMultipleHorizonsFound)).To(BeTrue())
+		})
+
+		It("rejects a second primary dashboard", func() {
+			instance := GetHorizon(secondaryName)
+			instance.Labels = horizonv1.HorizonRoleSelector(horizonv1.HorizonRolePrimary)
+			err := k8sClient.Update(ctx, instance)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(
+				"Horizon dashboard is already the primary dashboard of namespace"))
+		})
+
+		It("scopes the endpoint predicate to the selected instance", func() {
+			byName := horizonv1.HorizonEndpointChangedPredicateForName(primaryName.Name)
+			bySelector := horizonv1.HorizonEndpointChangedPredicateForSelector(
+				horizonv1.HorizonRoleSelector(horizonv1.HorizonRolePrimary))
+
+			for _, name := range []types.NamespacedName{primaryName, secondaryName} {
+				oldInstance := GetHorizon(name)
+				newInstance := oldInstance.DeepCopy()
+				newInstance.Status.Endpoint = "http://" + name.Name + ".example.com"
+				isPrimary := name == primaryName
+
+				updated := event.UpdateEvent{ObjectOld: oldInstance, ObjectNew: newInstance}
+				Expect(byName.Update(updated)).To(Equal(isPrimary))
+				Expect(bySelector.Update(updated)).To(Equal(isPrimary))
+
+				unchanged := event.UpdateEvent{ObjectOld: oldInstance, ObjectNew: oldInstance.DeepCopy()}
+				Expect(byName.Update(unchanged)).To(BeFalse())
+				Expect(bySelector.Update(unchanged)).To(BeFalse())
+
+				deleted := event.DeleteEvent{Object: oldInstance}
+				Expect(byName.Delete(deleted)).To(Equal(isPrimary))
+				Expect(bySelector.Delete(deleted)).To(Equal(isPrimary))
+			}
+		})
+	})
+})
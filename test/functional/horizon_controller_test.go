@@ -3,6 +3,7 @@ package functional_test
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	. "github.com/onsi/ginkgo/v2" //revive:disable:dot-imports
 	. "github.com/onsi/gomega"    //revive:disable:dot-imports
@@ -10,14 +11,26 @@ import (
 	//revive:disable-next-line:dot-imports
 	. "github.com/openstack-k8s-operators/lib-common/modules/common/test/helpers"
 
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	k8s_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	horizonv1 "github.com/openstack-k8s-operators/horizon-operator/api/v1beta1"
 	"github.com/openstack-k8s-operators/horizon-operator/internal/horizon"
 	memcachedv1 "github.com/openstack-k8s-operators/infra-operator/apis/memcached/v1beta1"
+	redisv1 "github.com/openstack-k8s-operators/infra-operator/apis/redis/v1beta1"
 	topologyv1 "github.com/openstack-k8s-operators/infra-operator/apis/topology/v1beta1"
 	condition "github.com/openstack-k8s-operators/lib-common/modules/common/condition"
+	"github.com/openstack-k8s-operators/lib-common/modules/common/service"
+	mariadbv1 "github.com/openstack-k8s-operators/mariadb-operator/api/v1beta1"
 )
 
 var _ = Describe("Horizon controller", func() {
@@ -98,6 +111,8 @@ var _ = Describe("Horizon controller", func() {
 				condition.CreateServiceReadyCondition,
 				condition.DeploymentReadyCondition,
 				condition.TLSInputReadyCondition,
+				horizonv1.HorizonConfigOverwriteReadyCondition,
+				horizonv1.HorizonPodDisruptionBudgetReadyCondition,
 			} {
 				th.ExpectCondition(
 					horizonName,
@@ -324,7 +339,7 @@ var _ = Describe("Horizon controller", func() {
 			Expect(deployment.Spec.Template.Spec.Containers[1].Env).
 				To(ContainElement(corev1.EnvVar{Name: "ENABLE_WATCHER", Value: "no", ValueFrom: nil}))
 			Expect(deployment.Spec.Template.Spec.Containers[1].Env).
-				To(ContainElement(corev1.EnvVar{Name: "ENABLE_OCTAVIA", Value: "yes", ValueFrom: nil}))
+				To(ContainElement(corev1.EnvVar{Name: "ENABLE_OCTAVIA", Value: "no", ValueFrom: nil}))
 		})
 		It("Should have liveness, readiness and startup Probes defined", func() {
 			deployment := th.GetDeployment(deploymentName)
@@ -366,16 +381,16 @@ var _ = Describe("Horizon controller", func() {
 			)
 		})
 
-		It("still shows the deployment progressing in DeploymentReadyCondition when rollout hits ProgressDeadlineExceeded", func() {
+		It("shows the rollout stuck in DeploymentReadyCondition when it hits ProgressDeadlineExceeded", func() {
 			th.SimulateDeploymentProgressDeadlineExceeded(deploymentName)
-			th.ExpectConditionWithDetails(
-				horizonName,
-				ConditionGetterFunc(HorizonConditionGetter),
-				condition.DeploymentReadyCondition,
-				corev1.ConditionFalse,
-				condition.RequestedReason,
-				condition.DeploymentReadyRunningMessage,
-			)
+			Eventually(func(g Gomega) {
+				conditions := HorizonConditionGetter(horizonName)
+				c := conditions.Get(condition.DeploymentReadyCondition)
+				g.Expect(c).ToNot(BeNil())
+				g.Expect(c.Status).To(Equal(corev1.ConditionFalse))
+				g.Expect(c.Reason).To(Equal(condition.ErrorReason))
+				g.Expect(c.Severity).To(Equal(condition.SeverityWarning))
+			}, timeout, interval).Should(Succeed())
 			th.ExpectCondition(
 				horizonName,
 				ConditionGetterFunc(HorizonConditionGetter),
@@ -475,7 +490,12 @@ var _ = Describe("Horizon controller", func() {
 			Expect(deployment.Spec.Template.Spec.Containers[1].Env).
 				To(ContainElement(corev1.EnvVar{Name: "ENABLE_WATCHER", Value: "yes", ValueFrom: nil}))
 			Expect(deployment.Spec.Template.Spec.Containers[1].Env).
-				To(ContainElement(corev1.EnvVar{Name: "ENABLE_OCTAVIA", Value: "yes", ValueFrom: nil}))
+				To(ContainElement(corev1.EnvVar{Name: "ENABLE_OCTAVIA", Value: "no", ValueFrom: nil}))
+		})
+		It("should report the watcher plugin as enabled", func() {
+			Eventually(func() []string {
+				return GetHorizon(horizonName).Status.EnabledPlugins
+			}, timeout, interval).Should(Equal([]string{"watcher"}))
 		})
 	})
 
@@ -537,7 +557,7 @@ var _ = Describe("Horizon controller", func() {
 			Expect(deployment.Spec.Template.Spec.Containers[1].Env).
 				To(ContainElement(corev1.EnvVar{Name: "ENABLE_CLOUDKITTY", Value: "yes", ValueFrom: nil}))
 			Expect(deployment.Spec.Template.Spec.Containers[1].Env).
-				To(ContainElement(corev1.EnvVar{Name: "ENABLE_OCTAVIA", Value: "yes", ValueFrom: nil}))
+				To(ContainElement(corev1.EnvVar{Name: "ENABLE_OCTAVIA", Value: "no", ValueFrom: nil}))
 		})
 	})
 
@@ -969,4 +989,1601 @@ var _ = Describe("Horizon controller", func() {
 			}, timeout, interval).Should(Succeed())
 		})
 	})
+
+	When("SSO is configured", func() {
+		var ssoSecretName string
+		BeforeEach(func() {
+			ssoSecretName = "horizon-sso"
+			DeferCleanup(th.DeleteInstance, CreateHorizon(horizonName, GetSSOHorizonSpec(ssoSecretName)))
+			DeferCleanup(
+				k8sClient.Delete, ctx, CreateHorizonSecret(namespace, SecretName))
+			DeferCleanup(infra.DeleteMemcached, infra.CreateMemcached(namespace, "memcached", memcachedSpec))
+			infra.SimulateMemcachedReady(types.NamespacedName{
+				Name:      "memcached",
+				Namespace: namespace,
+			})
+			keystoneAPI := keystone.CreateKeystoneAPI(namespace)
+			DeferCleanup(keystone.DeleteKeystoneAPI, keystoneAPI)
+		})
+
+		It("waits for the SSO credentials secret", func() {
+			th.ExpectConditionWithDetails(
+				horizonName,
+				ConditionGetterFunc(HorizonConditionGetter),
+				condition.InputReadyCondition,
+				corev1.ConditionFalse,
+				condition.ErrorReason,
+				condition.InputReadyWaitingMessage,
+			)
+		})
+
+		It("renders the WebSSO configuration", func() {
+			DeferCleanup(
+				k8sClient.Delete, ctx, CreateSSOCredentialsSecret(namespace, ssoSecretName))
+			th.ExpectCondition(
+				horizonName,
+				ConditionGetterFunc(HorizonConditionGetter),
+				condition.ServiceConfigReadyCondition,
+				corev1.ConditionTrue,
+			)
+			cm := th.GetConfigMap(types.NamespacedName{
+				Namespace: horizonName.Namespace,
+				Name:      horizonName.Name + "-config-data",
+			})
+			Expect(cm.Data["local_settings.py"]).Should(ContainSubstring("WEBSSO_ENABLED = True"))
+			Expect(cm.Data["local_settings.py"]).Should(ContainSubstring("(\"credentials\", _(\"Keystone Credentials\")),"))
+			Expect(cm.Data["local_settings.py"]).Should(ContainSubstring("(\"okta\", _(\"Okta\")),"))
+			Expect(cm.Data["local_settings.py"]).Should(ContainSubstring("(\"adfs\", _(\"adfs\")),"))
+			Expect(cm.Data["local_settings.py"]).Should(ContainSubstring("\"okta\": (\"okta-idp\", \"openid\"),"))
+			Expect(cm.Data["local_settings.py"]).Should(ContainSubstring("\"adfs\": (\"adfs-idp\", \"saml2\"),"))
+			Expect(cm.Data["local_settings.py"]).Should(ContainSubstring("WEBSSO_INITIAL_CHOICE = \"okta\""))
+			Expect(cm.Data["httpd.conf"]).Should(
+				ContainSubstring("OIDCProviderMetadataURL \"https://okta.example.com/.well-known/openid-configuration\""))
+			Expect(cm.Data["httpd.conf"]).Should(ContainSubstring("OIDCClientID \"horizon\""))
+		})
+
+		It("exposes the client credentials to the horizon container", func() {
+			DeferCleanup(
+				k8sClient.Delete, ctx, CreateSSOCredentialsSecret(namespace, ssoSecretName))
+			Eventually(func(g Gomega) {
+				container := th.GetDeployment(deploymentName).Spec.Template.Spec.Containers[1]
+				g.Expect(container.Env).To(ContainElement(corev1.EnvVar{
+					Name: "OIDC_CLIENT_SECRET",
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: ssoSecretName},
+							Key:                  "ClientSecret",
+						},
+					},
+				}))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("rolls the deployment when the SSO credentials change", func() {
+			DeferCleanup(
+				k8sClient.Delete, ctx, CreateSSOCredentialsSecret(namespace, ssoSecretName))
+			th.SimulateDeploymentReplicaReady(deploymentName)
+
+			originalHash := GetEnvVarValue(
+				th.GetDeployment(deploymentName).Spec.Template.Spec.Containers[1].Env,
+				"CONFIG_HASH",
+				"",
+			)
+			Expect(originalHash).NotTo(BeEmpty())
+
+			th.UpdateSecret(types.NamespacedName{
+				Name:      ssoSecretName,
+				Namespace: namespace,
+			},
+				"ClientSecret",
+				[]byte("rotated-client-secret"),
+			)
+
+			Eventually(func(g Gomega) {
+				newHash := GetEnvVarValue(
+					th.GetDeployment(deploymentName).Spec.Template.Spec.Containers[1].Env,
+					"CONFIG_HASH",
+					"",
+				)
+				g.Expect(newHash).NotTo(BeEmpty())
+				g.Expect(newHash).NotTo(Equal(originalHash))
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("typed settings are provided", func() {
+		BeforeEach(func() {
+			spec := GetDefaultHorizonSpec()
+			spec["settings"] = map[string]any{
+				"sessionTimeout": 7200,
+				"timeZone":       "Europe/Rome",
+				"consoleType":    "SPICE",
+				"passwordValidator": map[string]any{
+					"regex":    "^.{12,}$",
+					"helpText": "Use at least 12 characters",
+				},
+			}
+			DeferCleanup(th.DeleteInstance, CreateHorizon(horizonName, spec))
+			DeferCleanup(
+				k8sClient.Delete, ctx, CreateHorizonSecret(namespace, SecretName))
+			DeferCleanup(infra.DeleteMemcached, infra.CreateMemcached(namespace, "memcached", memcachedSpec))
+			infra.SimulateMemcachedReady(types.NamespacedName{
+				Name:      "memcached",
+				Namespace: namespace,
+			})
+			keystoneAPI := keystone.CreateKeystoneAPI(namespace)
+			DeferCleanup(keystone.DeleteKeystoneAPI, keystoneAPI)
+		})
+
+		It("renders the settings snippet", func() {
+			Eventually(func(g Gomega) {
+				cm := th.GetConfigMap(types.NamespacedName{
+					Namespace: horizonName.Namespace,
+					Name:      horizonName.Name + "-config-data",
+				})
+				g.Expect(cm).ShouldNot(BeNil())
+				conf := cm.Data["0100_horizon_settings.py"]
+				g.Expect(conf).Should(ContainSubstring("SESSION_TIMEOUT = 7200"))
+				g.Expect(conf).Should(ContainSubstring("TIME_ZONE = \"Europe/Rome\""))
+				g.Expect(conf).Should(ContainSubstring("CONSOLE_TYPE = \"SPICE\""))
+				g.Expect(conf).Should(ContainSubstring(
+					"HORIZON_CONFIG[\"password_validator\"] = {\"regex\": \"^.{12,}$\", \"help_text\": _(\"Use at least 12 characters\")}"))
+				g.Expect(cm.Data["horizon.json"]).Should(ContainSubstring("local_settings.d/0100_horizon_settings.py"))
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("defaultConfigOverwrite files are provided", func() {
+		BeforeEach(func() {
+			spec := GetDefaultHorizonSpec()
+			spec["defaultConfigOverwrite"] = map[string]any{
+				"nova_policy.yaml": "\"os_compute_api:servers:create\": \"rule:admin_api\"",
+				"_50_plugin.py":    "HORIZON_CONFIG['foo'] = True",
+				"10-headers.conf":  "Header set X-Frame-Options DENY",
+			}
+			DeferCleanup(th.DeleteInstance, CreateHorizon(horizonName, spec))
+			DeferCleanup(
+				k8sClient.Delete, ctx, CreateHorizonSecret(namespace, SecretName))
+			DeferCleanup(infra.DeleteMemcached, infra.CreateMemcached(namespace, "memcached", memcachedSpec))
+			infra.SimulateMemcachedReady(types.NamespacedName{
+				Name:      "memcached",
+				Namespace: namespace,
+			})
+			keystoneAPI := keystone.CreateKeystoneAPI(namespace)
+			DeferCleanup(keystone.DeleteKeystoneAPI, keystoneAPI)
+		})
+
+		It("places each file according to its name", func() {
+			Eventually(func(g Gomega) {
+				cm := th.GetConfigMap(types.NamespacedName{
+					Namespace: horizonName.Namespace,
+					Name:      horizonName.Name + "-config-data",
+				})
+				g.Expect(cm).ShouldNot(BeNil())
+				g.Expect(cm.Data).Should(HaveKey("nova_policy.yaml"))
+				conf := cm.Data["horizon.json"]
+				g.Expect(conf).Should(ContainSubstring("\"dest\": \"/etc/openstack-dashboard/nova_policy.yaml\""))
+				g.Expect(conf).Should(ContainSubstring("\"dest\": \"/etc/openstack-dashboard/local_settings.d/_50_plugin.py\""))
+				g.Expect(conf).Should(ContainSubstring("\"dest\": \"/etc/httpd/conf_custom/10-headers.conf\""))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("reports the files as placed", func() {
+			th.ExpectCondition(
+				horizonName,
+				ConditionGetterFunc(HorizonConditionGetter),
+				horizonv1.HorizonConfigOverwriteReadyCondition,
+				corev1.ConditionTrue,
+			)
+		})
+	})
+
+	When("service policies are configured", func() {
+		var policyName types.NamespacedName
+		BeforeEach(func() {
+			policyName = types.NamespacedName{
+				Name:      "nova-policy",
+				Namespace: namespace,
+			}
+			spec := GetDefaultHorizonSpec()
+			spec["policies"] = map[string]any{
+				"compute": map[string]any{
+					"configMapName": policyName.Name,
+				},
+			}
+			DeferCleanup(th.DeleteInstance, CreateHorizon(horizonName, spec))
+			DeferCleanup(
+				k8sClient.Delete, ctx, CreateHorizonSecret(namespace, SecretName))
+			DeferCleanup(infra.DeleteMemcached, infra.CreateMemcached(namespace, "memcached", memcachedSpec))
+			infra.SimulateMemcachedReady(types.NamespacedName{
+				Name:      "memcached",
+				Namespace: namespace,
+			})
+			keystoneAPI := keystone.CreateKeystoneAPI(namespace)
+			DeferCleanup(keystone.DeleteKeystoneAPI, keystoneAPI)
+		})
+
+		It("waits for the policy ConfigMap", func() {
+			th.ExpectConditionWithDetails(
+				horizonName,
+				ConditionGetterFunc(HorizonConditionGetter),
+				condition.InputReadyCondition,
+				corev1.ConditionFalse,
+				condition.ErrorReason,
+				condition.InputReadyWaitingMessage,
+			)
+		})
+
+		It("mounts the policy file and wires it in POLICY_FILES", func() {
+			DeferCleanup(k8sClient.Delete, ctx, CreatePolicyConfigMap(
+				namespace, policyName.Name, "\"os_compute_api:servers:create\": \"rule:admin_api\""))
+			Eventually(func(g Gomega) {
+				cm := th.GetConfigMap(types.NamespacedName{
+					Namespace: horizonName.Namespace,
+					Name:      horizonName.Name + "-config-data",
+				})
+				g.Expect(cm).ShouldNot(BeNil())
+				g.Expect(cm.Data["0100_horizon_settings.py"]).Should(
+					ContainSubstring("\"compute\": \"compute_policy.yaml\","))
+				g.Expect(cm.Data["horizon.json"]).Should(
+					ContainSubstring("\"source\": \"/var/lib/config-data/policies/compute/compute_policy.yaml\""))
+				g.Expect(cm.Data["horizon.json"]).Should(
+					ContainSubstring("\"dest\": \"/etc/openstack-dashboard/compute_policy.yaml\""))
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				d := th.GetDeployment(deploymentName)
+				g.Expect(d.Spec.Template.Spec.Volumes).To(ContainElement(
+					HaveField("Name", "policy-compute")))
+				g.Expect(d.Spec.Template.Spec.Containers[1].VolumeMounts).To(ContainElement(corev1.VolumeMount{
+					Name:      "policy-compute",
+					MountPath: "/var/lib/config-data/policies/compute",
+					ReadOnly:  true,
+				}))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("rolls the deployment when the policy changes", func() {
+			DeferCleanup(k8sClient.Delete, ctx, CreatePolicyConfigMap(
+				namespace, policyName.Name, "\"os_compute_api:servers:create\": \"rule:admin_api\""))
+			th.SimulateDeploymentReplicaReady(deploymentName)
+
+			originalHash := GetEnvVarValue(
+				th.GetDeployment(deploymentName).Spec.Template.Spec.Containers[1].Env,
+				"CONFIG_HASH",
+				"",
+			)
+			Expect(originalHash).NotTo(BeEmpty())
+
+			Eventually(func(g Gomega) {
+				cm := th.GetConfigMap(policyName)
+				cm.Data["policy.yaml"] = "\"os_compute_api:servers:create\": \"\""
+				g.Expect(k8sClient.Update(ctx, cm)).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				newHash := GetEnvVarValue(
+					th.GetDeployment(deploymentName).Spec.Template.Spec.Containers[1].Env,
+					"CONFIG_HASH",
+					"",
+				)
+				g.Expect(newHash).NotTo(BeEmpty())
+				g.Expect(newHash).NotTo(Equal(originalHash))
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("dashboard plugins are overridden", func() {
+		BeforeEach(func() {
+			spec := GetDefaultHorizonSpec()
+			spec["plugins"] = map[string]any{
+				"octavia": "enabled",
+				"watcher": "disabled",
+			}
+			DeferCleanup(th.DeleteInstance, CreateHorizon(horizonName, spec))
+			DeferCleanup(
+				k8sClient.Delete, ctx, CreateHorizonSecret(namespace, SecretName))
+			DeferCleanup(infra.DeleteMemcached, infra.CreateMemcached(namespace, "memcached", memcachedSpec))
+			infra.SimulateMemcachedReady(types.NamespacedName{
+				Name:      "memcached",
+				Namespace: namespace,
+			})
+			keystoneAPI := keystone.CreateKeystoneAPI(namespace)
+			DeferCleanup(keystone.DeleteKeystoneAPI, keystoneAPI)
+			th.CreateUnstructured(map[string]any{
+				"apiVersion": "keystone.openstack.org/v1beta1",
+				"kind":       "KeystoneService",
+				"metadata": map[string]any{
+					"name":      "watcher",
+					"namespace": namespace,
+				},
+				"spec": map[string]any{
+					"enabled":            true,
+					"passwordSelector":   "WatcherPassword",
+					"secret":             "osp-secret",
+					"serviceDescription": "Watcher Service",
+					"serviceName":        "watcher",
+					"serviceType":        "infra-optim",
+					"serviceUser":        "watcher",
+				},
+			})
+		})
+
+		It("applies the overrides regardless of the KeystoneServices", func() {
+			Eventually(func(g Gomega) {
+				env := th.GetDeployment(deploymentName).Spec.Template.Spec.Containers[1].Env
+				g.Expect(env).To(ContainElement(corev1.EnvVar{Name: "ENABLE_OCTAVIA", Value: "yes"}))
+				g.Expect(env).To(ContainElement(corev1.EnvVar{Name: "ENABLE_WATCHER", Value: "no"}))
+				g.Expect(env).To(ContainElement(corev1.EnvVar{Name: "ENABLE_HEAT", Value: "no"}))
+			}, timeout, interval).Should(Succeed())
+			Eventually(func() []string {
+				return GetHorizon(horizonName).Status.EnabledPlugins
+			}, timeout, interval).Should(Equal([]string{"octavia"}))
+		})
+	})
+
+	When("a keystone service is created after the deployment", func() {
+		BeforeEach(func() {
+			DeferCleanup(th.DeleteInstance, CreateHorizon(horizonName, GetDefaultHorizonSpec()))
+			DeferCleanup(
+				k8sClient.Delete, ctx, CreateHorizonSecret(namespace, SecretName))
+			DeferCleanup(infra.DeleteMemcached, infra.CreateMemcached(namespace, "memcached", memcachedSpec))
+			infra.SimulateMemcachedReady(types.NamespacedName{
+				Name:      "memcached",
+				Namespace: namespace,
+			})
+			keystoneAPI := keystone.CreateKeystoneAPI(namespace)
+			DeferCleanup(keystone.DeleteKeystoneAPI, keystoneAPI)
+			th.ExpectCondition(
+				horizonName,
+				ConditionGetterFunc(HorizonConditionGetter),
+				condition.ServiceConfigReadyCondition,
+				corev1.ConditionTrue,
+			)
+		})
+
+		It("emits an Event only when the enabled plugins change", func() {
+			pluginEvents := func(g Gomega) []corev1.Event {
+				events := &corev1.EventList{}
+				g.Expect(k8sClient.List(ctx, events, client.InNamespace(namespace))).Should(Succeed())
+				res := []corev1.Event{}
+				for _, e := range events.Items {
+					if e.InvolvedObject.Name == horizonName.Name && e.Reason == "EnabledPluginsChanged" {
+						res = append(res, e)
+					}
+				}
+				return res
+			}
+			// the first set of plugins is not a change
+			Consistently(func(g Gomega) {
+				g.Expect(pluginEvents(g)).To(BeEmpty())
+			}, timeout, interval).Should(Succeed())
+
+			th.CreateUnstructured(map[string]any{
+				"apiVersion": "keystone.openstack.org/v1beta1",
+				"kind":       "KeystoneService",
+				"metadata": map[string]any{
+					"name":      "watcher",
+					"namespace": namespace,
+				},
+				"spec": map[string]any{
+					"enabled":            true,
+					"passwordSelector":   "WatcherPassword",
+					"secret":             "osp-secret",
+					"serviceDescription": "Watcher Service",
+					"serviceName":        "watcher",
+					"serviceType":        "infra-optim",
+					"serviceUser":        "watcher",
+				},
+			})
+			Eventually(func(g Gomega) {
+				g.Expect(pluginEvents(g)).To(ContainElement(
+					HaveField("Message", "Enabled dashboard plugins changed from [] to [watcher]")))
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("autoscaling is enabled", func() {
+		var hpaName types.NamespacedName
+
+		BeforeEach(func() {
+			hpaName = types.NamespacedName{
+				Name:      horizonName.Name,
+				Namespace: namespace,
+			}
+			spec := GetDefaultHorizonSpec()
+			spec["autoscaling"] = map[string]any{
+				"minReplicas":                       2,
+				"maxReplicas":                       5,
+				"targetMemoryUtilizationPercentage": 70,
+			}
+			DeferCleanup(th.DeleteInstance, CreateHorizon(horizonName, spec))
+			DeferCleanup(
+				k8sClient.Delete, ctx, CreateHorizonSecret(namespace, SecretName))
+			DeferCleanup(infra.DeleteMemcached, infra.CreateMemcached(namespace, "memcached", memcachedSpec))
+			infra.SimulateMemcachedReady(types.NamespacedName{
+				Name:      "memcached",
+				Namespace: namespace,
+			})
+			keystoneAPI := keystone.CreateKeystoneAPI(namespace)
+			DeferCleanup(keystone.DeleteKeystoneAPI, keystoneAPI)
+		})
+
+		It("creates the HorizontalPodAutoscaler", func() {
+			Eventually(func(g Gomega) {
+				hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+				g.Expect(k8sClient.Get(ctx, hpaName, hpa)).Should(Succeed())
+				g.Expect(hpa.Spec.ScaleTargetRef.Name).To(Equal(deploymentName.Name))
+				g.Expect(hpa.Spec.MinReplicas).To(Equal(ptr.To[int32](2)))
+				g.Expect(hpa.Spec.MaxReplicas).To(Equal(int32(5)))
+				g.Expect(hpa.Spec.Metrics).To(HaveLen(1))
+				g.Expect(hpa.Spec.Metrics[0].Resource.Name).To(Equal(corev1.ResourceMemory))
+			}, timeout, interval).Should(Succeed())
+			th.ExpectCondition(
+				horizonName,
+				ConditionGetterFunc(HorizonConditionGetter),
+				horizonv1.HorizonAutoscalingReadyCondition,
+				corev1.ConditionTrue,
+			)
+		})
+
+		It("starts the Deployment with minReplicas and keeps the scaled replicas", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(th.GetDeployment(deploymentName).Spec.Replicas).To(Equal(ptr.To[int32](2)))
+			}, timeout, interval).Should(Succeed())
+
+			// simulate the HorizontalPodAutoscaler scaling the Deployment
+			Eventually(func(g Gomega) {
+				depl := th.GetDeployment(deploymentName)
+				depl.Spec.Replicas = ptr.To[int32](4)
+				g.Expect(k8sClient.Update(ctx, depl)).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+			Eventually(func(g Gomega) {
+				horizon := GetHorizon(horizonName)
+				horizon.Spec.CustomServiceConfig = "SESSION_TIMEOUT = 3600"
+				g.Expect(k8sClient.Update(ctx, horizon)).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			Consistently(func(g Gomega) {
+				g.Expect(th.GetDeployment(deploymentName).Spec.Replicas).To(Equal(ptr.To[int32](4)))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("deletes the HorizontalPodAutoscaler when autoscaling is removed", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, hpaName, &autoscalingv2.HorizontalPodAutoscaler{})).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				horizon := GetHorizon(horizonName)
+				horizon.Spec.Autoscaling = nil
+				g.Expect(k8sClient.Update(ctx, horizon)).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				err := k8sClient.Get(ctx, hpaName, &autoscalingv2.HorizontalPodAutoscaler{})
+				g.Expect(k8s_errors.IsNotFound(err)).To(BeTrue())
+				g.Expect(GetHorizon(horizonName).Status.Conditions.Has(
+					horizonv1.HorizonAutoscalingReadyCondition)).To(BeFalse())
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("more than one replica is requested", func() {
+		var pdbName types.NamespacedName
+
+		BeforeEach(func() {
+			pdbName = types.NamespacedName{
+				Name:      horizonName.Name,
+				Namespace: namespace,
+			}
+			spec := GetDefaultHorizonSpec()
+			spec["replicas"] = 3
+			DeferCleanup(th.DeleteInstance, CreateHorizon(horizonName, spec))
+			DeferCleanup(
+				k8sClient.Delete, ctx, CreateHorizonSecret(namespace, SecretName))
+			DeferCleanup(infra.DeleteMemcached, infra.CreateMemcached(namespace, "memcached", memcachedSpec))
+			infra.SimulateMemcachedReady(types.NamespacedName{
+				Name:      "memcached",
+				Namespace: namespace,
+			})
+			keystoneAPI := keystone.CreateKeystoneAPI(namespace)
+			DeferCleanup(keystone.DeleteKeystoneAPI, keystoneAPI)
+		})
+
+		It("creates a PodDisruptionBudget allowing one disrupted pod", func() {
+			Eventually(func(g Gomega) {
+				pdb := &policyv1.PodDisruptionBudget{}
+				g.Expect(k8sClient.Get(ctx, pdbName, pdb)).Should(Succeed())
+				g.Expect(pdb.Spec.Selector.MatchLabels).To(
+					Equal(th.GetDeployment(deploymentName).Spec.Selector.MatchLabels))
+				g.Expect(pdb.Spec.MaxUnavailable).To(Equal(ptr.To(intstr.FromInt32(1))))
+				g.Expect(pdb.Spec.MinAvailable).To(BeNil())
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("reports the PodDisruptionBudget in its own condition", func() {
+			th.ExpectConditionWithDetails(
+				horizonName,
+				ConditionGetterFunc(HorizonConditionGetter),
+				horizonv1.HorizonPodDisruptionBudgetReadyCondition,
+				corev1.ConditionTrue,
+				condition.ReadyReason,
+				horizonv1.HorizonPodDisruptionBudgetReadyMessage,
+			)
+		})
+
+		It("applies the podDisruptionBudget override", func() {
+			Eventually(func(g Gomega) {
+				horizon := GetHorizon(horizonName)
+				horizon.Spec.PodDisruptionBudget = &horizonv1.HorizonPodDisruptionBudgetSpec{
+					MinAvailable: ptr.To(intstr.FromString("50%")),
+				}
+				g.Expect(k8sClient.Update(ctx, horizon)).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				pdb := &policyv1.PodDisruptionBudget{}
+				g.Expect(k8sClient.Get(ctx, pdbName, pdb)).Should(Succeed())
+				g.Expect(pdb.Spec.MinAvailable).To(Equal(ptr.To(intstr.FromString("50%"))))
+				g.Expect(pdb.Spec.MaxUnavailable).To(BeNil())
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("deletes the PodDisruptionBudget when scaled down to one replica", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, pdbName, &policyv1.PodDisruptionBudget{})).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				horizon := GetHorizon(horizonName)
+				horizon.Spec.Replicas = ptr.To[int32](1)
+				g.Expect(k8sClient.Update(ctx, horizon)).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				err := k8sClient.Get(ctx, pdbName, &policyv1.PodDisruptionBudget{})
+				g.Expect(k8s_errors.IsNotFound(err)).To(BeTrue())
+			}, timeout, interval).Should(Succeed())
+			th.ExpectConditionWithDetails(
+				horizonName,
+				ConditionGetterFunc(HorizonConditionGetter),
+				horizonv1.HorizonPodDisruptionBudgetReadyCondition,
+				corev1.ConditionTrue,
+				condition.ReadyReason,
+				"PodDisruptionBudget not required with 1 replicas",
+			)
+		})
+	})
+
+	When("a rollout strategy is configured", func() {
+		BeforeEach(func() {
+			spec := GetDefaultHorizonSpec()
+			spec["rolloutStrategy"] = map[string]any{
+				"maxSurge":                "50%",
+				"maxUnavailable":          0,
+				"minReadySeconds":         10,
+				"progressDeadlineSeconds": 300,
+			}
+			DeferCleanup(th.DeleteInstance, CreateHorizon(horizonName, spec))
+			DeferCleanup(
+				k8sClient.Delete, ctx, CreateHorizonSecret(namespace, SecretName))
+			DeferCleanup(infra.DeleteMemcached, infra.CreateMemcached(namespace, "memcached", memcachedSpec))
+			infra.SimulateMemcachedReady(types.NamespacedName{
+				Name:      "memcached",
+				Namespace: namespace,
+			})
+			keystoneAPI := keystone.CreateKeystoneAPI(namespace)
+			DeferCleanup(keystone.DeleteKeystoneAPI, keystoneAPI)
+		})
+
+		It("sets the rolling update parameters of the Deployment", func() {
+			Eventually(func(g Gomega) {
+				depl := th.GetDeployment(deploymentName)
+				g.Expect(depl.Spec.Strategy.RollingUpdate).ToNot(BeNil())
+				g.Expect(depl.Spec.Strategy.RollingUpdate.MaxSurge).To(Equal(ptr.To(intstr.FromString("50%"))))
+				g.Expect(depl.Spec.Strategy.RollingUpdate.MaxUnavailable).To(Equal(ptr.To(intstr.FromInt32(0))))
+				g.Expect(depl.Spec.MinReadySeconds).To(Equal(int32(10)))
+				g.Expect(depl.Spec.ProgressDeadlineSeconds).To(Equal(ptr.To[int32](300)))
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("the session key strategy is set", func() {
+		var configMapName types.NamespacedName
+
+		createHorizon := func(sessionKey map[string]any) {
+			configMapName = types.NamespacedName{
+				Namespace: horizonName.Namespace,
+				Name:      horizonName.Name + "-config-data",
+			}
+			spec := GetDefaultHorizonSpec()
+			spec["sessionKey"] = sessionKey
+			DeferCleanup(th.DeleteInstance, CreateHorizon(horizonName, spec))
+			DeferCleanup(
+				k8sClient.Delete, ctx, CreateHorizonSecret(namespace, SecretName))
+			DeferCleanup(infra.DeleteMemcached, infra.CreateMemcached(namespace, "memcached", memcachedSpec))
+			infra.SimulateMemcachedReady(types.NamespacedName{
+				Name:      "memcached",
+				Namespace: namespace,
+			})
+			keystoneAPI := keystone.CreateKeystoneAPI(namespace)
+			DeferCleanup(keystone.DeleteKeystoneAPI, keystoneAPI)
+		}
+
+		It("uses the generation counter as session key prefix", func() {
+			createHorizon(map[string]any{
+				"strategy":   "generation",
+				"generation": 1,
+			})
+			Eventually(func(g Gomega) {
+				conf := th.GetConfigMap(configMapName).Data["local_settings.py"]
+				g.Expect(conf).To(ContainSubstring("'KEY_PREFIX': 'horizon-1',"))
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				horizon := GetHorizon(horizonName)
+				horizon.Spec.SessionKey.Generation = 2
+				g.Expect(k8sClient.Update(ctx, horizon)).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				conf := th.GetConfigMap(configMapName).Data["local_settings.py"]
+				g.Expect(conf).To(ContainSubstring("'KEY_PREFIX': 'horizon-2',"))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("keeps a stable session key prefix across unrelated changes", func() {
+			createHorizon(map[string]any{
+				"strategy": "stable",
+			})
+			var prefix string
+			Eventually(func(g Gomega) {
+				conf := th.GetConfigMap(configMapName).Data["local_settings.py"]
+				g.Expect(conf).NotTo(ContainSubstring("os.environ['CONFIG_HASH']"))
+				for _, line := range strings.Split(conf, "\n") {
+					if strings.Contains(line, "'KEY_PREFIX'") {
+						prefix = line
+					}
+				}
+				g.Expect(prefix).NotTo(BeEmpty())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				horizon := GetHorizon(horizonName)
+				horizon.Spec.CustomServiceConfig = "SESSION_TIMEOUT = 3600"
+				g.Expect(k8sClient.Update(ctx, horizon)).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				cm := th.GetConfigMap(configMapName)
+				g.Expect(cm.Data["9999_custom_settings.py"]).To(Equal("SESSION_TIMEOUT = 3600"))
+				g.Expect(cm.Data["local_settings.py"]).To(ContainSubstring(prefix))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("keeps the stable session key prefix across a SECRET_KEY rotation", func() {
+			createHorizon(map[string]any{
+				"strategy": "stable",
+			})
+			var prefix string
+			Eventually(func(g Gomega) {
+				conf := th.GetConfigMap(configMapName).Data["local_settings.py"]
+				for _, line := range strings.Split(conf, "\n") {
+					if strings.Contains(line, "'KEY_PREFIX'") {
+						prefix = line
+					}
+				}
+				g.Expect(prefix).NotTo(BeEmpty())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				horizon := GetHorizon(horizonName)
+				horizon.Annotations = map[string]string{
+					horizonv1.SecretKeyRotationAnnotation: "1",
+				}
+				g.Expect(k8sClient.Update(ctx, horizon)).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				conf := th.GetConfigMap(configMapName).Data["local_settings.py"]
+				g.Expect(conf).To(ContainSubstring("SECRET_KEY_FALLBACKS"))
+				g.Expect(conf).To(ContainSubstring(prefix))
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("the sessions are stored in signed cookies", func() {
+		BeforeEach(func() {
+			spec := GetDefaultHorizonSpec()
+			spec["sessionBackend"] = "signed_cookies"
+			DeferCleanup(th.DeleteInstance, CreateHorizon(horizonName, spec))
+			DeferCleanup(
+				k8sClient.Delete, ctx, CreateHorizonSecret(namespace, SecretName))
+			keystoneAPI := keystone.CreateKeystoneAPI(namespace)
+			DeferCleanup(keystone.DeleteKeystoneAPI, keystoneAPI)
+		})
+
+		It("doesn't wait for memcached", func() {
+			Eventually(func(g Gomega) {
+				conf := th.GetConfigMap(types.NamespacedName{
+					Namespace: horizonName.Namespace,
+					Name:      horizonName.Name + "-config-data",
+				}).Data["local_settings.py"]
+				g.Expect(conf).To(ContainSubstring(
+					"SESSION_ENGINE = 'django.contrib.sessions.backends.signed_cookies'"))
+				g.Expect(conf).NotTo(ContainSubstring("CACHES = {"))
+			}, timeout, interval).Should(Succeed())
+			th.GetDeployment(deploymentName)
+
+			conditions := HorizonConditionGetter(horizonName)
+			Expect(conditions.Has(condition.MemcachedReadyCondition)).To(BeFalse())
+		})
+	})
+
+	When("the sessions are stored in redis", func() {
+		var redisName types.NamespacedName
+
+		BeforeEach(func() {
+			redisName = types.NamespacedName{
+				Name:      "redis",
+				Namespace: namespace,
+			}
+			spec := GetDefaultHorizonSpec()
+			spec["sessionBackend"] = "redis"
+			spec["redisInstance"] = redisName.Name
+			DeferCleanup(th.DeleteInstance, CreateHorizon(horizonName, spec))
+			DeferCleanup(
+				k8sClient.Delete, ctx, CreateHorizonSecret(namespace, SecretName))
+			keystoneAPI := keystone.CreateKeystoneAPI(namespace)
+			DeferCleanup(keystone.DeleteKeystoneAPI, keystoneAPI)
+		})
+
+		It("waits for the Redis instance and renders the redis cache", func() {
+			th.ExpectCondition(
+				horizonName,
+				ConditionGetterFunc(HorizonConditionGetter),
+				horizonv1.HorizonRedisReadyCondition,
+				corev1.ConditionFalse,
+			)
+
+			DeferCleanup(th.DeleteInstance, th.CreateUnstructured(map[string]any{
+				"apiVersion": "redis.openstack.org/v1beta1",
+				"kind":       "Redis",
+				"metadata": map[string]any{
+					"name":      redisName.Name,
+					"namespace": redisName.Namespace,
+				},
+				"spec": map[string]any{
+					"containerImage": "test://redis",
+					"replicas":       1,
+				},
+			}))
+			Eventually(func(g Gomega) {
+				redis := &redisv1.Redis{}
+				g.Expect(k8sClient.Get(ctx, redisName, redis)).Should(Succeed())
+				redis.Status.Conditions.MarkTrue(condition.ReadyCondition, condition.ReadyMessage)
+				g.Expect(k8sClient.Status().Update(ctx, redis)).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+			// the host and the port are read from the Service of the Redis
+			// instance
+			redisSvc := &corev1.Service{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      redisName.Name,
+					Namespace: redisName.Namespace,
+				},
+				Spec: corev1.ServiceSpec{
+					Ports: []corev1.ServicePort{{Name: "redis", Port: 6379}},
+				},
+			}
+			Expect(k8sClient.Create(ctx, redisSvc)).Should(Succeed())
+			DeferCleanup(k8sClient.Delete, ctx, redisSvc)
+
+			th.ExpectCondition(
+				horizonName,
+				ConditionGetterFunc(HorizonConditionGetter),
+				horizonv1.HorizonRedisReadyCondition,
+				corev1.ConditionTrue,
+			)
+			Eventually(func(g Gomega) {
+				conf := th.GetConfigMap(types.NamespacedName{
+					Namespace: horizonName.Namespace,
+					Name:      horizonName.Name + "-config-data",
+				}).Data["local_settings.py"]
+				g.Expect(conf).To(ContainSubstring("'BACKEND': 'django.core.cache.backends.redis.RedisCache'"))
+				g.Expect(conf).To(ContainSubstring(
+					fmt.Sprintf("'LOCATION': 'redis://redis.%s.svc:6379'", namespace)))
+				g.Expect(conf).NotTo(ContainSubstring("ssl_ca_certs"))
+				g.Expect(conf).To(ContainSubstring(
+					"SESSION_ENGINE = 'django.contrib.sessions.backends.cache'"))
+			}, timeout, interval).Should(Succeed())
+
+			conditions := HorizonConditionGetter(horizonName)
+			Expect(conditions.Has(condition.MemcachedReadyCondition)).To(BeFalse())
+		})
+	})
+
+	When("the sessions are stored in a database", func() {
+		var dbName types.NamespacedName
+		var accountName types.NamespacedName
+
+		BeforeEach(func() {
+			dbName = types.NamespacedName{Namespace: namespace, Name: horizonName.Name}
+			accountName = types.NamespacedName{Namespace: namespace, Name: "horizon"}
+			spec := GetDefaultHorizonSpec()
+			spec["sessionBackend"] = "database"
+			spec["database"] = map[string]any{
+				"databaseInstance": "openstack",
+			}
+			DeferCleanup(
+				mariadb.DeleteDBService,
+				mariadb.CreateDBService(
+					namespace,
+					"openstack",
+					corev1.ServiceSpec{
+						Ports: []corev1.ServicePort{{Port: 3306}},
+					},
+				),
+			)
+			DeferCleanup(th.DeleteInstance, CreateHorizon(horizonName, spec))
+			DeferCleanup(
+				k8sClient.Delete, ctx, CreateHorizonSecret(namespace, SecretName))
+			keystoneAPI := keystone.CreateKeystoneAPI(namespace)
+			DeferCleanup(keystone.DeleteKeystoneAPI, keystoneAPI)
+		})
+
+		It("waits for the database before creating the Deployment", func() {
+			th.ExpectCondition(
+				horizonName,
+				ConditionGetterFunc(HorizonConditionGetter),
+				mariadbv1.MariaDBAccountReadyCondition,
+				corev1.ConditionTrue,
+			)
+			th.ExpectCondition(
+				horizonName,
+				ConditionGetterFunc(HorizonConditionGetter),
+				condition.DBReadyCondition,
+				corev1.ConditionFalse,
+			)
+			th.ExpectCondition(
+				horizonName,
+				ConditionGetterFunc(HorizonConditionGetter),
+				condition.DBSyncReadyCondition,
+				corev1.ConditionUnknown,
+			)
+			Consistently(func(g Gomega) {
+				deployment := &appsv1.Deployment{}
+				g.Expect(k8s_errors.IsNotFound(
+					k8sClient.Get(ctx, deploymentName, deployment))).To(BeTrue())
+			}, timeout, interval).Should(Succeed())
+
+			conditions := HorizonConditionGetter(horizonName)
+			Expect(conditions.Has(condition.MemcachedReadyCondition)).To(BeFalse())
+		})
+
+		It("creates the MariaDBDatabase owned by the instance and the db sync Job", func() {
+			db := mariadb.GetMariaDBDatabase(dbName)
+			Expect(db.Spec.Name).To(Equal("horizon"))
+			Expect(db.OwnerReferences).To(HaveLen(1))
+			Expect(db.OwnerReferences[0].Name).To(Equal(horizonName.Name))
+
+			mariadb.SimulateMariaDBAccountCompleted(accountName)
+			mariadb.SimulateMariaDBDatabaseCompleted(dbName)
+			th.ExpectCondition(
+				horizonName,
+				ConditionGetterFunc(HorizonConditionGetter),
+				condition.DBReadyCondition,
+				corev1.ConditionTrue,
+			)
+
+			account := mariadb.GetMariaDBAccount(accountName)
+			configMapName := types.NamespacedName{
+				Namespace: horizonName.Namespace,
+				Name:      horizonName.Name + "-config-data",
+			}
+			Eventually(func(g Gomega) {
+				cm := th.GetConfigMap(configMapName)
+				g.Expect(cm.Data["local_settings.py"]).To(
+					ContainSubstring(fmt.Sprintf("'USER': '%s'", account.Spec.UserName)))
+				g.Expect(cm.Data).To(HaveKey("my.cnf"))
+			}, timeout, interval).Should(Succeed())
+
+			job := th.GetJob(types.NamespacedName{
+				Namespace: horizonName.Namespace,
+				Name:      horizonName.Name + "-db-sync",
+			})
+			Expect(job.Spec.Template.Spec.Containers[0].Env).To(ContainElement(
+				HaveField("ValueFrom.SecretKeyRef.Name", account.Spec.Secret)))
+		})
+	})
+
+	When("SECRET_KEY rotation is requested", func() {
+		var configMapName types.NamespacedName
+		var secretName types.NamespacedName
+
+		BeforeEach(func() {
+			configMapName = types.NamespacedName{
+				Namespace: horizonName.Namespace,
+				Name:      horizonName.Name + "-config-data",
+			}
+			secretName = types.NamespacedName{
+				Namespace: horizonName.Namespace,
+				Name:      horizonName.Name,
+			}
+			spec := GetDefaultHorizonSpec()
+			DeferCleanup(th.DeleteInstance, CreateHorizon(horizonName, spec))
+			DeferCleanup(
+				k8sClient.Delete, ctx, CreateHorizonSecret(namespace, SecretName))
+			DeferCleanup(infra.DeleteMemcached, infra.CreateMemcached(namespace, "memcached", memcachedSpec))
+			infra.SimulateMemcachedReady(types.NamespacedName{
+				Name:      "memcached",
+				Namespace: namespace,
+			})
+			keystoneAPI := keystone.CreateKeystoneAPI(namespace)
+			DeferCleanup(keystone.DeleteKeystoneAPI, keystoneAPI)
+		})
+
+		It("rotates the key and keeps the previous one as fallback", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(GetHorizon(horizonName).Status.SecretKeyLastRotated).NotTo(BeNil())
+			}, timeout, interval).Should(Succeed())
+			originalKey := th.GetSecret(secretName).Data[horizon.SecretKeySelector]
+			Expect(originalKey).NotTo(BeEmpty())
+			originalHash := GetEnvVarValue(
+				th.GetDeployment(deploymentName).Spec.Template.Spec.Containers[1].Env,
+				"CONFIG_HASH",
+				"",
+			)
+			Expect(th.GetConfigMap(configMapName).Data["local_settings.py"]).NotTo(
+				ContainSubstring("SECRET_KEY_FALLBACKS"))
+
+			Eventually(func(g Gomega) {
+				horizon := GetHorizon(horizonName)
+				horizon.Annotations = map[string]string{
+					horizonv1.SecretKeyRotationAnnotation: "1",
+				}
+				g.Expect(k8sClient.Update(ctx, horizon)).Should(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				secret := th.GetSecret(secretName)
+				g.Expect(secret.Data[horizon.PreviousSecretKeySelector]).To(Equal(originalKey))
+				g.Expect(secret.Data[horizon.SecretKeySelector]).NotTo(Equal(originalKey))
+				g.Expect(GetHorizon(horizonName).Status.SecretKeyRotationTrigger).To(Equal("1"))
+				g.Expect(th.GetConfigMap(configMapName).Data["local_settings.py"]).To(
+					ContainSubstring("SECRET_KEY_FALLBACKS"))
+				newHash := GetEnvVarValue(
+					th.GetDeployment(deploymentName).Spec.Template.Spec.Containers[1].Env,
+					"CONFIG_HASH",
+					"",
+				)
+				g.Expect(newHash).NotTo(Equal(originalHash))
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("several Horizon instances are deployed in the namespace", func() {
+		var dashboardName types.NamespacedName
+
+		BeforeEach(func() {
+			dashboardName = types.NamespacedName{
+				Name:      "dashboard",
+				Namespace: namespace,
+			}
+			DeferCleanup(th.DeleteInstance, CreateHorizon(horizonName, GetDefaultHorizonSpec()))
+			DeferCleanup(th.DeleteInstance, CreateHorizon(dashboardName, GetDefaultHorizonSpec()))
+			DeferCleanup(
+				k8sClient.Delete, ctx, CreateHorizonSecret(namespace, SecretName))
+			DeferCleanup(infra.DeleteMemcached, infra.CreateMemcached(namespace, "memcached", memcachedSpec))
+			infra.SimulateMemcachedReady(types.NamespacedName{
+				Name:      "memcached",
+				Namespace: namespace,
+			})
+			keystoneAPI := keystone.CreateKeystoneAPI(namespace)
+			DeferCleanup(keystone.DeleteKeystoneAPI, keystoneAPI)
+		})
+
+		It("names the child objects after each instance", func() {
+			for _, name := range []types.NamespacedName{horizonName, dashboardName} {
+				depl := th.GetDeployment(name)
+				Expect(depl.OwnerReferences[0].Name).To(Equal(name.Name))
+				Expect(th.GetSecret(name).Data).To(HaveKey(horizon.SecretKeySelector))
+				Expect(th.GetService(name).Spec.Selector).To(
+					HaveKeyWithValue("owner", name.Name))
+				Expect(th.GetConfigMap(types.NamespacedName{
+					Namespace: name.Namespace,
+					Name:      name.Name + "-scripts",
+				})).NotTo(BeNil())
+
+				var scriptsVolume *corev1.Volume
+				for i := range depl.Spec.Template.Spec.Volumes {
+					if depl.Spec.Template.Spec.Volumes[i].Name == "scripts" {
+						scriptsVolume = &depl.Spec.Template.Spec.Volumes[i]
+					}
+				}
+				Expect(scriptsVolume).NotTo(BeNil())
+				Expect(scriptsVolume.ConfigMap.Name).To(Equal(name.Name + "-scripts"))
+			}
+		})
+	})
+
+	When("an instance not named horizon has objects named after the service", func() {
+		var dashboardName types.NamespacedName
+
+		BeforeEach(func() {
+			dashboardName = types.NamespacedName{
+				Name:      "dashboard",
+				Namespace: namespace,
+			}
+			DeferCleanup(th.DeleteInstance, CreateHorizon(dashboardName, GetDefaultHorizonSpec()))
+			DeferCleanup(
+				k8sClient.Delete, ctx, CreateHorizonSecret(namespace, SecretName))
+			DeferCleanup(infra.DeleteMemcached, infra.CreateMemcached(namespace, "memcached", memcachedSpec))
+			infra.SimulateMemcachedReady(types.NamespacedName{
+				Name:      "memcached",
+				Namespace: namespace,
+			})
+			keystoneAPI := keystone.CreateKeystoneAPI(namespace)
+			DeferCleanup(keystone.DeleteKeystoneAPI, keystoneAPI)
+		})
+
+		It("deletes them once the Deployment named after the instance is ready", func() {
+			dashboard := GetHorizon(dashboardName)
+			legacyName := types.NamespacedName{
+				Name:      horizon.ServiceName,
+				Namespace: namespace,
+			}
+			// simulate the Secret created before the child objects were named
+			// after the instance
+			legacySecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      legacyName.Name,
+					Namespace: legacyName.Namespace,
+					OwnerReferences: []metav1.OwnerReference{{
+						APIVersion: "horizon.openstack.org/v1beta1",
+						Kind:       "Horizon",
+						Name:       dashboard.Name,
+						UID:        dashboard.UID,
+						Controller: ptr.To(true),
+					}},
+				},
+				StringData: map[string]string{horizon.SecretKeySelector: "legacy"},
+			}
+			Expect(k8sClient.Create(ctx, legacySecret)).Should(Succeed())
+
+			th.SimulateDeploymentReplicaReady(dashboardName)
+
+			Eventually(func(g Gomega) {
+				err := k8sClient.Get(ctx, legacyName, &corev1.Secret{})
+				g.Expect(k8s_errors.IsNotFound(err)).To(BeTrue())
+			}, timeout, interval).Should(Succeed())
+			Expect(th.GetSecret(dashboardName).Data).To(HaveKey(horizon.SecretKeySelector))
+		})
+	})
+
+	When("the SECRET_KEY is supplied through spec.secret", func() {
+		var ospSecretName types.NamespacedName
+
+		BeforeEach(func() {
+			ospSecretName = types.NamespacedName{
+				Namespace: namespace,
+				Name:      SecretName,
+			}
+			DeferCleanup(infra.DeleteMemcached, infra.CreateMemcached(namespace, "memcached", memcachedSpec))
+			infra.SimulateMemcachedReady(types.NamespacedName{
+				Name:      "memcached",
+				Namespace: namespace,
+			})
+			keystoneAPI := keystone.CreateKeystoneAPI(namespace)
+			DeferCleanup(keystone.DeleteKeystoneAPI, keystoneAPI)
+		})
+
+		It("uses the supplied key", func() {
+			userKey := strings.Repeat("0123456789", 6)
+			DeferCleanup(k8sClient.Delete, ctx, th.CreateSecret(ospSecretName, map[string][]byte{
+				"HorizonSecretKey": []byte(userKey),
+			}))
+			DeferCleanup(th.DeleteInstance, CreateHorizon(horizonName, GetDefaultHorizonSpec()))
+
+			Eventually(func(g Gomega) {
+				secret := th.GetSecret(horizonName)
+				g.Expect(string(secret.Data[horizon.SecretKeySelector])).To(Equal(userKey))
+			}, timeout, interval).Should(Succeed())
+			th.GetDeployment(deploymentName)
+		})
+
+		It("reports a weak key in the InputReady condition", func() {
+			DeferCleanup(th.DeleteInstance, CreateHorizon(horizonName, GetDefaultHorizonSpec()))
+			// the Secret is created after the Horizon CR, hence the webhook
+			// can't reject the key
+			DeferCleanup(k8sClient.Delete, ctx, th.CreateSecret(ospSecretName, map[string][]byte{
+				"HorizonSecretKey": []byte("too-short"),
+			}))
+
+			Eventually(func(g Gomega) {
+				conditions := HorizonConditionGetter(horizonName)
+				inputReady := conditions.Get(condition.InputReadyCondition)
+				g.Expect(inputReady).NotTo(BeNil())
+				g.Expect(inputReady.Status).To(Equal(corev1.ConditionFalse))
+				g.Expect(inputReady.Message).To(
+					ContainSubstring("SECRET_KEY is too short: 9 characters, at least 50 are required"))
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("a profile is set", func() {
+		var configMapName types.NamespacedName
+
+		BeforeEach(func() {
+			configMapName = types.NamespacedName{
+				Namespace: horizonName.Namespace,
+				Name:      horizonName.Name + "-config-data",
+			}
+			DeferCleanup(
+				k8sClient.Delete, ctx, CreateHorizonSecret(namespace, SecretName))
+			DeferCleanup(infra.DeleteMemcached, infra.CreateMemcached(namespace, "memcached", memcachedSpec))
+			infra.SimulateMemcachedReady(types.NamespacedName{
+				Name:      "memcached",
+				Namespace: namespace,
+			})
+			keystoneAPI := keystone.CreateKeystoneAPI(namespace)
+			DeferCleanup(keystone.DeleteKeystoneAPI, keystoneAPI)
+		})
+
+		It("hides the admin dashboard for the tenant profile", func() {
+			spec := GetDefaultHorizonSpec()
+			spec["profile"] = "tenant"
+			DeferCleanup(th.DeleteInstance, CreateHorizon(horizonName, spec))
+
+			Eventually(func(g Gomega) {
+				cm := th.GetConfigMap(configMapName)
+				g.Expect(cm.Data["_9010_horizon_profile.py"]).To(ContainSubstring("DASHBOARD = 'admin'\nDISABLED = True"))
+				g.Expect(cm.Data["horizon.json"]).To(ContainSubstring(
+					"\"dest\": \"/usr/share/openstack-dashboard/openstack_dashboard/local/enabled/_9010_horizon_profile.py\""))
+				g.Expect(cm.Data["local_settings.py"]).To(ContainSubstring("OPENSTACK_ENDPOINT_TYPE = \"publicURL\""))
+			}, timeout, interval).Should(Succeed())
+
+			svc := th.GetService(horizonName)
+			Expect(svc.Annotations).To(HaveKeyWithValue(service.AnnotationEndpointKey, "public"))
+			Expect(svc.Annotations).To(HaveKeyWithValue(service.AnnotationIngressCreateKey, "true"))
+		})
+
+		It("exposes the admin profile through the internal Service only", func() {
+			spec := GetDefaultHorizonSpec()
+			spec["profile"] = "admin"
+			DeferCleanup(th.DeleteInstance, CreateHorizon(horizonName, spec))
+
+			Eventually(func(g Gomega) {
+				cm := th.GetConfigMap(configMapName)
+				g.Expect(cm.Data["local_settings.py"]).To(ContainSubstring("OPENSTACK_ENDPOINT_TYPE = \"internalURL\""))
+				g.Expect(cm.Data["horizon.json"]).NotTo(ContainSubstring("_9010_horizon_profile.py"))
+			}, timeout, interval).Should(Succeed())
+
+			svc := th.GetService(horizonName)
+			Expect(svc.Annotations).To(HaveKeyWithValue(service.AnnotationEndpointKey, "internal"))
+			Expect(svc.Annotations).To(HaveKeyWithValue(service.AnnotationIngressCreateKey, "false"))
+		})
+
+		It("keeps every dashboard for the full profile", func() {
+			DeferCleanup(th.DeleteInstance, CreateHorizon(horizonName, GetDefaultHorizonSpec()))
+
+			Eventually(func(g Gomega) {
+				cm := th.GetConfigMap(configMapName)
+				g.Expect(cm.Data["local_settings.py"]).To(ContainSubstring("OPENSTACK_ENDPOINT_TYPE = \"publicURL\""))
+				g.Expect(cm.Data["horizon.json"]).NotTo(ContainSubstring("_9010_horizon_profile.py"))
+			}, timeout, interval).Should(Succeed())
+			Expect(GetHorizon(horizonName).Spec.Profile).To(Equal(horizonv1.ProfileFull))
+		})
+	})
+
+	When("the endpoint interfaces are set", func() {
+		BeforeEach(func() {
+			spec := GetDefaultHorizonSpec()
+			spec["endpointInterfaces"] = map[string]any{
+				"keystone":         "public",
+				"catalog":          "internal",
+				"secondaryCatalog": "public",
+			}
+			DeferCleanup(th.DeleteInstance, CreateHorizon(horizonName, spec))
+			DeferCleanup(
+				k8sClient.Delete, ctx, CreateHorizonSecret(namespace, SecretName))
+			DeferCleanup(infra.DeleteMemcached, infra.CreateMemcached(namespace, "memcached", memcachedSpec))
+			infra.SimulateMemcachedReady(types.NamespacedName{
+				Name:      "memcached",
+				Namespace: namespace,
+			})
+			keystoneAPI := keystone.CreateKeystoneAPI(namespace)
+			DeferCleanup(keystone.DeleteKeystoneAPI, keystoneAPI)
+		})
+
+		It("renders the selected endpoints", func() {
+			Eventually(func(g Gomega) {
+				cm := th.GetConfigMap(types.NamespacedName{
+					Namespace: horizonName.Namespace,
+					Name:      horizonName.Name + "-config-data",
+				})
+				conf := cm.Data["local_settings.py"]
+				g.Expect(conf).To(ContainSubstring(
+					"OPENSTACK_KEYSTONE_URL = \"http://keystone-public.openstack.svc:5000/v3\""))
+				g.Expect(conf).To(ContainSubstring("OPENSTACK_ENDPOINT_TYPE = \"internalURL\""))
+				g.Expect(conf).To(ContainSubstring("SECONDARY_ENDPOINT_TYPE = \"publicURL\""))
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("regions are listed", func() {
+		BeforeEach(func() {
+			keystoneAPI := keystone.CreateKeystoneAPI(namespace)
+			DeferCleanup(keystone.DeleteKeystoneAPI, keystoneAPI)
+
+			spec := GetDefaultHorizonSpec()
+			spec["regions"] = []map[string]any{
+				{"name": "az0"},
+				{
+					"name":               "regionTwo",
+					"keystoneURL":        "https://keystone.r2.example.com:5000",
+					"caBundleSecretName": "region-two-ca",
+				},
+				{
+					"name": "regionThree",
+					"keystoneAPI": map[string]any{
+						"name":      keystoneAPI.Name,
+						"namespace": keystoneAPI.Namespace,
+					},
+				},
+			}
+			otherRegion := GetExtraMounts("bar", "/var/log/bar")
+			otherRegion[0]["region"] = "az1"
+			spec["extraMounts"] = append(GetExtraMounts("foo", "/var/log/foo"), otherRegion...)
+
+			DeferCleanup(k8sClient.Delete, ctx, th.CreateCABundleSecret(types.NamespacedName{
+				Name:      "region-two-ca",
+				Namespace: namespace,
+			}))
+			DeferCleanup(th.DeleteInstance, CreateHorizon(horizonName, spec))
+			DeferCleanup(
+				k8sClient.Delete, ctx, CreateHorizonSecret(namespace, SecretName))
+			DeferCleanup(infra.DeleteMemcached, infra.CreateMemcached(namespace, "memcached", memcachedSpec))
+			infra.SimulateMemcachedReady(types.NamespacedName{
+				Name:      "memcached",
+				Namespace: namespace,
+			})
+		})
+
+		It("renders AVAILABLE_REGIONS", func() {
+			Eventually(func(g Gomega) {
+				cm := th.GetConfigMap(types.NamespacedName{
+					Namespace: horizonName.Namespace,
+					Name:      horizonName.Name + "-config-data",
+				})
+				conf := cm.Data["local_settings.py"]
+				g.Expect(conf).To(ContainSubstring(`AVAILABLE_REGIONS = [
+    ("http://keystone-internal.openstack.svc:5000/v3", "az0"),
+    ("https://keystone.r2.example.com:5000/v3", "regionTwo"),
+    ("http://keystone-internal.openstack.svc:5000/v3", "regionThree"),
+]`))
+				g.Expect(conf).To(ContainSubstring(
+					"OPENSTACK_SSL_CACERT = \"/run/openstack-dashboard/ca-bundle/ca-bundle.pem\""))
+			}, timeout, interval).Should(Succeed())
+		})
+
+		It("mounts the CA bundles and the extraMounts of the regions", func() {
+			dp := th.GetDeployment(deploymentName)
+			container := dp.Spec.Template.Spec.Containers[1]
+			Expect(container.Args).To(ContainElement(ContainSubstring("region_ca_setup")))
+			th.AssertVolumeMountPathExists("region-ca-bundle",
+				"/run/openstack-dashboard/ca-bundle", "", container.VolumeMounts)
+			th.AssertVolumeMountPathExists("region-ca-bundle-0",
+				"/var/lib/config-data/ca-bundles/region-two-ca.pem", "tls-ca-bundle.pem", container.VolumeMounts)
+			th.AssertVolumeMountPathExists("foo", "/var/log/foo", "", container.VolumeMounts)
+			for _, mount := range container.VolumeMounts {
+				Expect(mount.MountPath).NotTo(Equal("/var/log/bar"))
+			}
+		})
+	})
+
+	When("an ingress is requested", func() {
+		BeforeEach(func() {
+			spec := GetDefaultHorizonSpec()
+			spec["ingress"] = map[string]any{
+				"hostname":         "dashboard.example.com",
+				"ingressClassName": "nginx",
+				"annotations": map[string]any{
+					"nginx.ingress.kubernetes.io/proxy-body-size": "0",
+				},
+			}
+			DeferCleanup(th.DeleteInstance, CreateHorizon(horizonName, spec))
+			DeferCleanup(
+				k8sClient.Delete, ctx, CreateHorizonSecret(namespace, SecretName))
+			DeferCleanup(infra.DeleteMemcached, infra.CreateMemcached(namespace, "memcached", memcachedSpec))
+			infra.SimulateMemcachedReady(types.NamespacedName{
+				Name:      "memcached",
+				Namespace: namespace,
+			})
+			keystoneAPI := keystone.CreateKeystoneAPI(namespace)
+			DeferCleanup(keystone.DeleteKeystoneAPI, keystoneAPI)
+		})
+
+		It("creates an Ingress and reports its URL as endpoint", func() {
+			ingress := &networkingv1.Ingress{}
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, horizonName, ingress)).To(Succeed())
+			}, timeout, interval).Should(Succeed())
+			Expect(ingress.Spec.IngressClassName).To(Equal(ptr.To("nginx")))
+			Expect(ingress.Annotations).To(HaveKeyWithValue("nginx.ingress.kubernetes.io/proxy-body-size", "0"))
+			Expect(ingress.Spec.Rules).To(HaveLen(1))
+			Expect(ingress.Spec.Rules[0].Host).To(Equal("dashboard.example.com"))
+			Expect(ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Name).To(Equal(horizonName.Name))
+			Expect(metav1.IsControlledBy(ingress, GetHorizon(horizonName))).To(BeTrue())
+
+			svc := th.GetService(horizonName)
+			Expect(svc.Annotations).To(HaveKeyWithValue(service.AnnotationIngressCreateKey, "false"))
+
+			Eventually(func(g Gomega) {
+				g.Expect(GetHorizon(horizonName).Status.Endpoint).To(Equal("http://dashboard.example.com"))
+			}, timeout, interval).Should(Succeed())
+			th.ExpectCondition(
+				horizonName,
+				ConditionGetterFunc(HorizonConditionGetter),
+				horizonv1.HorizonIngressReadyCondition,
+				corev1.ConditionTrue,
+			)
+		})
+
+		It("deletes the Ingress when the ingress section is removed", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, horizonName, &networkingv1.Ingress{})).To(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				instance := GetHorizon(horizonName)
+				instance.Spec.Ingress = nil
+				g.Expect(k8sClient.Update(ctx, instance)).To(Succeed())
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				err := k8sClient.Get(ctx, horizonName, &networkingv1.Ingress{})
+				g.Expect(k8s_errors.IsNotFound(err)).To(BeTrue())
+				g.Expect(GetHorizon(horizonName).Status.Conditions.Has(horizonv1.HorizonIngressReadyCondition)).To(BeFalse())
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+
+	When("pod TLS is enabled and an ingress is requested", func() {
+		BeforeEach(func() {
+			spec := GetTLSHorizonSpec()
+			spec["ingress"] = map[string]any{
+				"hostname": "dashboard.example.com",
+			}
+			DeferCleanup(th.DeleteInstance, CreateHorizon(horizonName, spec))
+			DeferCleanup(
+				k8sClient.Delete, ctx, CreateHorizonSecret(namespace, SecretName))
+			DeferCleanup(k8sClient.Delete, ctx, th.CreateCABundleSecret(types.NamespacedName{
+				Name:      CABundleSecretName,
+				Namespace: namespace,
+			}))
+			DeferCleanup(k8sClient.Delete, ctx, th.CreateCertSecret(types.NamespacedName{
+				Name:      InternalCertSecretName,
+				Namespace: namespace,
+			}))
+			DeferCleanup(infra.DeleteMemcached, infra.CreateMemcached(namespace, "memcached", memcachedSpec))
+			infra.SimulateMemcachedReady(types.NamespacedName{
+				Name:      "memcached",
+				Namespace: namespace,
+			})
+			keystoneAPI := keystone.CreateKeystoneAPI(namespace)
+			DeferCleanup(keystone.DeleteKeystoneAPI, keystoneAPI)
+		})
+
+		It("reaches the pods over HTTPS", func() {
+			ingress := &networkingv1.Ingress{}
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, horizonName, ingress)).To(Succeed())
+			}, timeout, interval).Should(Succeed())
+			Expect(ingress.Annotations).To(
+				HaveKeyWithValue("nginx.ingress.kubernetes.io/backend-protocol", "HTTPS"))
+		})
+	})
+
+	When("the dashboard is exposed through a Gateway", func() {
+		BeforeEach(func() {
+			spec := GetDefaultHorizonSpec()
+			spec["override"] = map[string]any{
+				"gateway": map[string]any{
+					"parentRef": map[string]any{"name": "public"},
+					"hostnames": []string{"dashboard.example.com"},
+				},
+			}
+			DeferCleanup(th.DeleteInstance, CreateHorizon(horizonName, spec))
+			DeferCleanup(
+				k8sClient.Delete, ctx, CreateHorizonSecret(namespace, SecretName))
+			DeferCleanup(infra.DeleteMemcached, infra.CreateMemcached(namespace, "memcached", memcachedSpec))
+			infra.SimulateMemcachedReady(types.NamespacedName{
+				Name:      "memcached",
+				Namespace: namespace,
+			})
+			keystoneAPI := keystone.CreateKeystoneAPI(namespace)
+			DeferCleanup(keystone.DeleteKeystoneAPI, keystoneAPI)
+		})
+
+		It("reports the missing Gateway API in the HTTPRoute condition", func() {
+			Eventually(func(g Gomega) {
+				instance := GetHorizon(horizonName)
+				cond := instance.Status.Conditions.Get(horizonv1.HorizonHTTPRouteReadyCondition)
+				g.Expect(cond).NotTo(BeNil())
+				g.Expect(cond.Status).To(Equal(corev1.ConditionFalse))
+				g.Expect(cond.Message).To(ContainSubstring("the Gateway API is not served by the cluster"))
+			}, timeout, interval).Should(Succeed())
+
+			svc := th.GetService(horizonName)
+			Expect(svc.Annotations).To(HaveKeyWithValue(service.AnnotationIngressCreateKey, "false"))
+		})
+	})
+
+	When("a web root is set", func() {
+		var configMapName types.NamespacedName
+
+		BeforeEach(func() {
+			configMapName = types.NamespacedName{
+				Namespace: horizonName.Namespace,
+				Name:      horizonName.Name + "-config-data",
+			}
+			DeferCleanup(
+				k8sClient.Delete, ctx, CreateHorizonSecret(namespace, SecretName))
+			DeferCleanup(infra.DeleteMemcached, infra.CreateMemcached(namespace, "memcached", memcachedSpec))
+			infra.SimulateMemcachedReady(types.NamespacedName{
+				Name:      "memcached",
+				Namespace: namespace,
+			})
+			keystoneAPI := keystone.CreateKeystoneAPI(namespace)
+			DeferCleanup(keystone.DeleteKeystoneAPI, keystoneAPI)
+		})
+
+		It("serves the dashboard under the web root", func() {
+			spec := GetDefaultHorizonSpec()
+			spec["webRoot"] = "/horizon"
+			DeferCleanup(th.DeleteInstance, CreateHorizon(horizonName, spec))
+
+			Eventually(func(g Gomega) {
+				cm := th.GetConfigMap(configMapName)
+				g.Expect(cm.Data["local_settings.py"]).To(ContainSubstring("WEBROOT = '/horizon/'"))
+				g.Expect(cm.Data["local_settings.py"]).To(ContainSubstring("LOGIN_URL = '/horizon/auth/login/'"))
+				g.Expect(cm.Data["local_settings.py"]).To(ContainSubstring("LOGOUT_URL = '/horizon/auth/logout/'"))
+				g.Expect(cm.Data["httpd.conf"]).To(ContainSubstring("Alias /horizon/static \"/usr/share/openstack-dashboard/static\""))
+				g.Expect(cm.Data["httpd.conf"]).To(ContainSubstring("WSGIScriptAlias /horizon \""))
+				g.Expect(cm.Data["httpd.conf"]).To(MatchRegexp(`RedirectMatch permanent  \^/\$ "http://.*/horizon"`))
+			}, timeout, interval).Should(Succeed())
+
+			deployment := th.GetDeployment(deploymentName)
+			Expect(deployment.Spec.Template.Spec.Containers[1].LivenessProbe.ProbeHandler.HTTPGet.Path).To(Equal("/horizon/auth/login/?next=/horizon/"))
+			Expect(deployment.Spec.Template.Spec.Containers[1].StartupProbe.ProbeHandler.HTTPGet.Path).To(Equal("/horizon/auth/login/?next=/horizon/"))
+		})
+
+		It("serves the dashboard at the root of the host name", func() {
+			spec := GetDefaultHorizonSpec()
+			spec["webRoot"] = "/"
+			DeferCleanup(th.DeleteInstance, CreateHorizon(horizonName, spec))
+
+			Eventually(func(g Gomega) {
+				cm := th.GetConfigMap(configMapName)
+				g.Expect(cm.Data["local_settings.py"]).To(ContainSubstring("WEBROOT = '/'"))
+				g.Expect(cm.Data["local_settings.py"]).To(ContainSubstring("LOGIN_URL = '/auth/login/'"))
+				g.Expect(cm.Data["httpd.conf"]).To(ContainSubstring("Alias /static \"/usr/share/openstack-dashboard/static\""))
+				g.Expect(cm.Data["httpd.conf"]).To(ContainSubstring("WSGIScriptAlias / \""))
+				g.Expect(cm.Data["httpd.conf"]).NotTo(ContainSubstring("RedirectMatch"))
+			}, timeout, interval).Should(Succeed())
+
+			deployment := th.GetDeployment(deploymentName)
+			Expect(deployment.Spec.Template.Spec.Containers[1].ReadinessProbe.ProbeHandler.HTTPGet.Path).To(Equal("/auth/login/?next=/"))
+		})
+	})
+
+	When("additional hostnames are set", func() {
+		BeforeEach(func() {
+			spec := GetDefaultHorizonSpec()
+			spec["additionalHostnames"] = []string{"dashboard.example.com", "cloud.example.org"}
+			DeferCleanup(th.DeleteInstance, CreateHorizon(horizonName, spec))
+			DeferCleanup(
+				k8sClient.Delete, ctx, CreateHorizonSecret(namespace, SecretName))
+			DeferCleanup(infra.DeleteMemcached, infra.CreateMemcached(namespace, "memcached", memcachedSpec))
+			infra.SimulateMemcachedReady(types.NamespacedName{
+				Name:      "memcached",
+				Namespace: namespace,
+			})
+			keystoneAPI := keystone.CreateKeystoneAPI(namespace)
+			DeferCleanup(keystone.DeleteKeystoneAPI, keystoneAPI)
+		})
+
+		It("accepts the requests for the additional hostnames", func() {
+			Eventually(func(g Gomega) {
+				cm := th.GetConfigMap(types.NamespacedName{
+					Namespace: horizonName.Namespace,
+					Name:      horizonName.Name + "-config-data",
+				})
+				conf := cm.Data["local_settings.py"]
+				g.Expect(conf).To(ContainSubstring("    \"dashboard.example.com\",\n    \"cloud.example.org\",\n]"))
+				g.Expect(conf).To(ContainSubstring("    \"http://dashboard.example.com\",\n    \"http://cloud.example.org\",\n]"))
+			}, timeout, interval).Should(Succeed())
+
+			Eventually(func(g Gomega) {
+				instance := GetHorizon(horizonName)
+				g.Expect(instance.Status.Hostnames).To(HaveLen(3))
+				g.Expect(instance.Status.Hostnames[1:]).To(Equal([]string{"dashboard.example.com", "cloud.example.org"}))
+			}, timeout, interval).Should(Succeed())
+
+			svc := th.GetService(horizonName)
+			Expect(svc.Annotations).To(HaveKeyWithValue(
+				horizonv1.AdditionalHostnamesAnnotation, "dashboard.example.com,cloud.example.org"))
+		})
+	})
+
+	When("the pod IPs are injected through the downward API", func() {
+		var configMapName types.NamespacedName
+
+		BeforeEach(func() {
+			configMapName = types.NamespacedName{
+				Namespace: horizonName.Namespace,
+				Name:      horizonName.Name + "-config-data",
+			}
+			DeferCleanup(
+				k8sClient.Delete, ctx, CreateHorizonSecret(namespace, SecretName))
+			DeferCleanup(infra.DeleteMemcached, infra.CreateMemcached(namespace, "memcached", memcachedSpec))
+			infra.SimulateMemcachedReady(types.NamespacedName{
+				Name:      "memcached",
+				Namespace: namespace,
+			})
+			keystoneAPI := keystone.CreateKeystoneAPI(namespace)
+			DeferCleanup(keystone.DeleteKeystoneAPI, keystoneAPI)
+		})
+
+		createHorizonWithEndpoint := func(endpointURL string) {
+			spec := GetDefaultHorizonSpec()
+			spec["override"] = map[string]any{
+				"service": map[string]any{
+					"endpointURL": endpointURL,
+				},
+			}
+			DeferCleanup(th.DeleteInstance, CreateHorizon(horizonName, spec))
+		}
+
+		expectPodIPEnvVars := func() {
+			deployment := th.GetDeployment(deploymentName)
+			fieldPaths := map[string]string{}
+			for _, envVar := range deployment.Spec.Template.Spec.Containers[1].Env {
+				if envVar.ValueFrom != nil && envVar.ValueFrom.FieldRef != nil {
+					fieldPaths[envVar.Name] = envVar.ValueFrom.FieldRef.FieldPath
+				}
+			}
+			Expect(fieldPaths).To(HaveKeyWithValue("POD_IP", "status.podIP"))
+			Expect(fieldPaths).To(HaveKeyWithValue("POD_IPS", "status.podIPs"))
+		}
+
+		expectAllowedHosts := func(host string) {
+			Eventually(func(g Gomega) {
+				conf := th.GetConfigMap(configMapName).Data["local_settings.py"]
+				g.Expect(conf).To(ContainSubstring(
+					"ALLOWED_HOSTS = get_pod_ips() + [\n    \"" + host + "\",\n]"))
+				g.Expect(conf).To(ContainSubstring(`os.environ.get("POD_IPS") or os.environ.get("POD_IP", "")`))
+				g.Expect(conf).NotTo(ContainSubstring("socket.SOCK_DGRAM"))
+			}, timeout, interval).Should(Succeed())
+		}
+
+		It("allows the pod IPs and an IPv4 endpoint", func() {
+			createHorizonWithEndpoint("http://192.0.2.10")
+			expectAllowedHosts("192.0.2.10")
+			expectPodIPEnvVars()
+		})
+
+		It("allows the pod IPs and an IPv6 endpoint between brackets", func() {
+			createHorizonWithEndpoint("http://[fd00:bad:cafe::10]")
+			expectAllowedHosts("[fd00:bad:cafe::10]")
+			expectPodIPEnvVars()
+		})
+
+		It("allows every pod IP and the endpoint host name on a dual-stack cluster", func() {
+			createHorizonWithEndpoint("https://horizon.example.com")
+			expectAllowedHosts("horizon.example.com")
+			expectPodIPEnvVars()
+		})
+	})
 })
@@ -18,6 +18,7 @@ package functional_test
 
 import (
 	"os"
+	"strings"
 
 	. "github.com/onsi/ginkgo/v2" //revive:disable:dot-imports
 	. "github.com/onsi/gomega"    //revive:disable:dot-imports
@@ -96,4 +97,649 @@ var _ = Describe("Horizon Webhook", func() {
 				"spec.topologyRef.namespace: Invalid value: \"namespace\": Customizing namespace field is not supported"),
 		)
 	})
+
+	It("rejects an SSO initialChoice not matching any identity provider", func() {
+		horizonSpec := GetSSOHorizonSpec("horizon-sso")
+		horizonSpec["sso"].(map[string]any)["initialChoice"] = "foo"
+		raw := map[string]any{
+			"apiVersion": "horizon.openstack.org/v1beta1",
+			"kind":       "Horizon",
+			"metadata": map[string]any{
+				"name":      "horizon",
+				"namespace": namespace,
+			},
+			"spec": horizonSpec,
+		}
+		unstructuredObj := &unstructured.Unstructured{Object: raw}
+		_, err := controllerutil.CreateOrPatch(
+			th.Ctx, th.K8sClient, unstructuredObj, func() error { return nil })
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(
+			ContainSubstring("spec.sso.initialChoice: Unsupported value: \"foo\""))
+	})
+
+	It("rejects OpenID Connect settings breaking out of the httpd directives", func() {
+		horizonSpec := GetSSOHorizonSpec("horizon-sso")
+		idp := horizonSpec["sso"].(map[string]any)["identityProviders"].([]map[string]any)[0]
+		idp["providerMetadataURL"] = "http://okta.example.com\"\nInclude /etc/passwd"
+		idp["clientID"] = "horizon\""
+		raw := map[string]any{
+			"apiVersion": "horizon.openstack.org/v1beta1",
+			"kind":       "Horizon",
+			"metadata": map[string]any{
+				"name":      "horizon",
+				"namespace": namespace,
+			},
+			"spec": horizonSpec,
+		}
+		unstructuredObj := &unstructured.Unstructured{Object: raw}
+		_, err := controllerutil.CreateOrPatch(
+			th.Ctx, th.K8sClient, unstructuredObj, func() error { return nil })
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(
+			ContainSubstring("spec.sso.identityProviders[0].providerMetadataURL: Invalid value"))
+		Expect(err.Error()).To(
+			ContainSubstring("providerMetadataURL must be an https URL"))
+		Expect(err.Error()).To(
+			ContainSubstring("spec.sso.identityProviders[0].clientID: Invalid value"))
+	})
+
+	When("A Horizon instance is created with SSO identity providers", func() {
+		BeforeEach(func() {
+			DeferCleanup(th.DeleteInstance, CreateHorizon(horizonName, GetSSOHorizonSpec("horizon-sso")))
+		})
+
+		It("defaults the identity provider displayName to its name", func() {
+			Horizon := GetHorizon(horizonName)
+			Expect(Horizon.Spec.SSO.IdentityProviders[1].DisplayName).Should(Equal("adfs"))
+		})
+	})
+
+	It("rejects an unknown settings timeZone", func() {
+		horizonSpec := GetDefaultHorizonSpec()
+		horizonSpec["settings"] = map[string]any{
+			"timeZone": "Mars/Olympus_Mons",
+		}
+		raw := map[string]any{
+			"apiVersion": "horizon.openstack.org/v1beta1",
+			"kind":       "Horizon",
+			"metadata": map[string]any{
+				"name":      "horizon",
+				"namespace": namespace,
+			},
+			"spec": horizonSpec,
+		}
+		unstructuredObj := &unstructured.Unstructured{Object: raw}
+		_, err := controllerutil.CreateOrPatch(
+			th.Ctx, th.K8sClient, unstructuredObj, func() error { return nil })
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(
+			ContainSubstring("spec.settings.timeZone: Invalid value: \"Mars/Olympus_Mons\": unknown time zone"))
+	})
+
+	It("rejects a settings passwordValidator regex which can't be compiled", func() {
+		horizonSpec := GetDefaultHorizonSpec()
+		horizonSpec["settings"] = map[string]any{
+			"passwordValidator": map[string]any{
+				"regex":    "^[a-z.{8,}$",
+				"helpText": "Use at least 8 lowercase characters",
+			},
+		}
+		raw := map[string]any{
+			"apiVersion": "horizon.openstack.org/v1beta1",
+			"kind":       "Horizon",
+			"metadata": map[string]any{
+				"name":      "horizon",
+				"namespace": namespace,
+			},
+			"spec": horizonSpec,
+		}
+		unstructuredObj := &unstructured.Unstructured{Object: raw}
+		_, err := controllerutil.CreateOrPatch(
+			th.Ctx, th.K8sClient, unstructuredObj, func() error { return nil })
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(
+			ContainSubstring("spec.settings.passwordValidator.regex: Invalid value: \"^[a-z.{8,}$\": " +
+				"error parsing regexp: missing closing ]"))
+	})
+
+	It("accepts a settings passwordValidator regex using Python lookaheads", func() {
+		horizonSpec := GetDefaultHorizonSpec()
+		horizonSpec["settings"] = map[string]any{
+			"passwordValidator": map[string]any{
+				"regex":    `^(?=.*\d)(?=.*[a-z]).{8,}$`,
+				"helpText": "Use at least 8 characters, including a digit",
+			},
+		}
+		DeferCleanup(th.DeleteInstance, CreateHorizon(horizonName, horizonSpec))
+		Expect(GetHorizon(horizonName).Spec.Settings.PasswordValidator.Regex).To(
+			Equal(`^(?=.*\d)(?=.*[a-z]).{8,}$`))
+	})
+
+	It("rejects a podDisruptionBudget which never allows an eviction", func() {
+		horizonSpec := GetDefaultHorizonSpec()
+		horizonSpec["replicas"] = 3
+		horizonSpec["podDisruptionBudget"] = map[string]any{
+			"minAvailable": 3,
+		}
+		raw := map[string]any{
+			"apiVersion": "horizon.openstack.org/v1beta1",
+			"kind":       "Horizon",
+			"metadata": map[string]any{
+				"name":      "horizon",
+				"namespace": namespace,
+			},
+			"spec": horizonSpec,
+		}
+		unstructuredObj := &unstructured.Unstructured{Object: raw}
+		_, err := controllerutil.CreateOrPatch(
+			th.Ctx, th.K8sClient, unstructuredObj, func() error { return nil })
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(
+			ContainSubstring("spec.podDisruptionBudget.minAvailable: Invalid value: \"3\": " +
+				"minAvailable must be lower than the 3 replicas"))
+
+		horizonSpec["podDisruptionBudget"] = map[string]any{
+			"maxUnavailable": 0,
+		}
+		_, err = controllerutil.CreateOrPatch(
+			th.Ctx, th.K8sClient, unstructuredObj, func() error { return nil })
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(
+			ContainSubstring("spec.podDisruptionBudget.maxUnavailable: Invalid value: \"0\": maxUnavailable may not be 0"))
+	})
+
+	It("rejects a DefaultConfigOverwrite of a file rendered by the operator", func() {
+		horizonSpec := GetDefaultHorizonSpec()
+		horizonSpec["defaultConfigOverwrite"] = map[string]any{
+			"local_settings.py": "DEBUG = True\n",
+		}
+		raw := map[string]any{
+			"apiVersion": "horizon.openstack.org/v1beta1",
+			"kind":       "Horizon",
+			"metadata": map[string]any{
+				"name":      "horizon",
+				"namespace": namespace,
+			},
+			"spec": horizonSpec,
+		}
+		unstructuredObj := &unstructured.Unstructured{Object: raw}
+		_, err := controllerutil.CreateOrPatch(
+			th.Ctx, th.K8sClient, unstructuredObj, func() error { return nil })
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(
+			ContainSubstring("spec.defaultConfigOverwrite[local_settings.py]: Forbidden: local_settings.py is rendered by the operator"))
+	})
+
+	It("rejects a DefaultConfigOverwrite key with no known destination", func() {
+		horizonSpec := GetDefaultHorizonSpec()
+		horizonSpec["defaultConfigOverwrite"] = map[string]any{
+			"README": "not a config file",
+		}
+		raw := map[string]any{
+			"apiVersion": "horizon.openstack.org/v1beta1",
+			"kind":       "Horizon",
+			"metadata": map[string]any{
+				"name":      "horizon",
+				"namespace": namespace,
+			},
+			"spec": horizonSpec,
+		}
+		unstructuredObj := &unstructured.Unstructured{Object: raw}
+		_, err := controllerutil.CreateOrPatch(
+			th.Ctx, th.K8sClient, unstructuredObj, func() error { return nil })
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(
+			ContainSubstring("spec.defaultConfigOverwrite[README]: Invalid value: \"README\": no known destination"))
+	})
+
+	It("rejects a customServiceConfig with a Python syntax error", func() {
+		horizonSpec := GetDefaultHorizonSpec()
+		horizonSpec["customServiceConfig"] = "OPENSTACK_HEAT_STACK = {\n    'enable_user_pass': False,\n"
+		raw := map[string]any{
+			"apiVersion": "horizon.openstack.org/v1beta1",
+			"kind":       "Horizon",
+			"metadata": map[string]any{
+				"name":      "horizon",
+				"namespace": namespace,
+			},
+			"spec": horizonSpec,
+		}
+		unstructuredObj := &unstructured.Unstructured{Object: raw}
+		_, err := controllerutil.CreateOrPatch(
+			th.Ctx, th.K8sClient, unstructuredObj, func() error { return nil })
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(
+			ContainSubstring("spec.customServiceConfig: Invalid value"))
+		Expect(err.Error()).To(
+			ContainSubstring("line 1: '{' was never closed"))
+	})
+
+	It("accepts a customServiceConfig with CRLF line endings", func() {
+		horizonSpec := GetDefaultHorizonSpec()
+		horizonSpec["customServiceConfig"] = "if DEBUG:\r\n" +
+			"    LOGGING = {\r\n" +
+			"        'version': 1,\r\n" +
+			"    }\r\n" +
+			"WELCOME = 'Welcome to \\\r\nOpenStack'\r\n"
+		DeferCleanup(th.DeleteInstance, CreateHorizon(horizonName, horizonSpec))
+		Expect(GetHorizon(horizonName).Spec.CustomServiceConfig).To(HavePrefix("if DEBUG:\r\n"))
+	})
+
+	It("accepts a customServiceConfig overriding a setting owned by the operator", func() {
+		horizonSpec := GetDefaultHorizonSpec()
+		horizonSpec["customServiceConfig"] = "ALLOWED_HOSTS = ['*']\n"
+		raw := map[string]any{
+			"apiVersion": "horizon.openstack.org/v1beta1",
+			"kind":       "Horizon",
+			"metadata": map[string]any{
+				"name":      "horizon",
+				"namespace": namespace,
+			},
+			"spec": horizonSpec,
+		}
+		unstructuredObj := &unstructured.Unstructured{Object: raw}
+		_, err := controllerutil.CreateOrPatch(
+			th.Ctx, th.K8sClient, unstructuredObj, func() error { return nil })
+		Expect(err).ShouldNot(HaveOccurred())
+		DeferCleanup(th.DeleteInstance, GetHorizon(horizonName))
+	})
+
+	It("rejects a policy for an unsupported service", func() {
+		horizonSpec := GetDefaultHorizonSpec()
+		horizonSpec["policies"] = map[string]any{
+			"dns": map[string]any{
+				"configMapName": "designate-policy",
+			},
+		}
+		raw := map[string]any{
+			"apiVersion": "horizon.openstack.org/v1beta1",
+			"kind":       "Horizon",
+			"metadata": map[string]any{
+				"name":      "horizon",
+				"namespace": namespace,
+			},
+			"spec": horizonSpec,
+		}
+		unstructuredObj := &unstructured.Unstructured{Object: raw}
+		_, err := controllerutil.CreateOrPatch(
+			th.Ctx, th.K8sClient, unstructuredObj, func() error { return nil })
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(
+			ContainSubstring("spec.policies[dns]: Unsupported value: \"dns\""))
+	})
+
+	It("rejects an unknown dashboard plugin", func() {
+		horizonSpec := GetDefaultHorizonSpec()
+		horizonSpec["plugins"] = map[string]any{
+			"barbican": "enabled",
+		}
+		raw := map[string]any{
+			"apiVersion": "horizon.openstack.org/v1beta1",
+			"kind":       "Horizon",
+			"metadata": map[string]any{
+				"name":      "horizon",
+				"namespace": namespace,
+			},
+			"spec": horizonSpec,
+		}
+		unstructuredObj := &unstructured.Unstructured{Object: raw}
+		_, err := controllerutil.CreateOrPatch(
+			th.Ctx, th.K8sClient, unstructuredObj, func() error { return nil })
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(
+			ContainSubstring("spec.plugins[barbican]: Unsupported value: \"barbican\""))
+	})
+
+	It("rejects minReplicas greater than maxReplicas", func() {
+		horizonSpec := GetDefaultHorizonSpec()
+		horizonSpec["autoscaling"] = map[string]any{
+			"minReplicas": 4,
+			"maxReplicas": 2,
+		}
+		raw := map[string]any{
+			"apiVersion": "horizon.openstack.org/v1beta1",
+			"kind":       "Horizon",
+			"metadata": map[string]any{
+				"name":      "horizon",
+				"namespace": namespace,
+			},
+			"spec": horizonSpec,
+		}
+		unstructuredObj := &unstructured.Unstructured{Object: raw}
+		_, err := controllerutil.CreateOrPatch(
+			th.Ctx, th.K8sClient, unstructuredObj, func() error { return nil })
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(
+			ContainSubstring("spec.autoscaling.minReplicas: Invalid value: 4"))
+	})
+
+	It("rejects both minAvailable and maxUnavailable", func() {
+		horizonSpec := GetDefaultHorizonSpec()
+		horizonSpec["podDisruptionBudget"] = map[string]any{
+			"minAvailable":   1,
+			"maxUnavailable": 1,
+		}
+		raw := map[string]any{
+			"apiVersion": "horizon.openstack.org/v1beta1",
+			"kind":       "Horizon",
+			"metadata": map[string]any{
+				"name":      "horizon",
+				"namespace": namespace,
+			},
+			"spec": horizonSpec,
+		}
+		unstructuredObj := &unstructured.Unstructured{Object: raw}
+		_, err := controllerutil.CreateOrPatch(
+			th.Ctx, th.K8sClient, unstructuredObj, func() error { return nil })
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(
+			ContainSubstring("spec.podDisruptionBudget.maxUnavailable: Forbidden"))
+	})
+
+	It("rejects a rollout strategy with no surge and no unavailable pod", func() {
+		horizonSpec := GetDefaultHorizonSpec()
+		horizonSpec["rolloutStrategy"] = map[string]any{
+			"maxSurge":       0,
+			"maxUnavailable": "0%",
+		}
+		raw := map[string]any{
+			"apiVersion": "horizon.openstack.org/v1beta1",
+			"kind":       "Horizon",
+			"metadata": map[string]any{
+				"name":      "horizon",
+				"namespace": namespace,
+			},
+			"spec": horizonSpec,
+		}
+		unstructuredObj := &unstructured.Unstructured{Object: raw}
+		_, err := controllerutil.CreateOrPatch(
+			th.Ctx, th.K8sClient, unstructuredObj, func() error { return nil })
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(
+			ContainSubstring("spec.rolloutStrategy.maxUnavailable: Invalid value: \"0%\""))
+	})
+
+	It("requires redisInstance with the redis session backend", func() {
+		horizonSpec := GetDefaultHorizonSpec()
+		horizonSpec["sessionBackend"] = "redis"
+		raw := map[string]any{
+			"apiVersion": "horizon.openstack.org/v1beta1",
+			"kind":       "Horizon",
+			"metadata": map[string]any{
+				"name":      "horizon",
+				"namespace": namespace,
+			},
+			"spec": horizonSpec,
+		}
+		unstructuredObj := &unstructured.Unstructured{Object: raw}
+		_, err := controllerutil.CreateOrPatch(
+			th.Ctx, th.K8sClient, unstructuredObj, func() error { return nil })
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(
+			ContainSubstring("spec.redisInstance: Required value"))
+	})
+
+	It("requires the database section with the database session backend", func() {
+		horizonSpec := GetDefaultHorizonSpec()
+		horizonSpec["sessionBackend"] = "database"
+		raw := map[string]any{
+			"apiVersion": "horizon.openstack.org/v1beta1",
+			"kind":       "Horizon",
+			"metadata": map[string]any{
+				"name":      "horizon",
+				"namespace": namespace,
+			},
+			"spec": horizonSpec,
+		}
+		unstructuredObj := &unstructured.Unstructured{Object: raw}
+		_, err := controllerutil.CreateOrPatch(
+			th.Ctx, th.K8sClient, unstructuredObj, func() error { return nil })
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(
+			ContainSubstring("spec.database: Required value"))
+	})
+
+	It("rejects a SECRET_KEY rotation interval shorter than an hour", func() {
+		horizonSpec := GetDefaultHorizonSpec()
+		horizonSpec["secretKeyRotation"] = map[string]any{
+			"interval": "10m",
+		}
+		raw := map[string]any{
+			"apiVersion": "horizon.openstack.org/v1beta1",
+			"kind":       "Horizon",
+			"metadata": map[string]any{
+				"name":      "horizon",
+				"namespace": namespace,
+			},
+			"spec": horizonSpec,
+		}
+		unstructuredObj := &unstructured.Unstructured{Object: raw}
+		_, err := controllerutil.CreateOrPatch(
+			th.Ctx, th.K8sClient, unstructuredObj, func() error { return nil })
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(
+			ContainSubstring("spec.secretKeyRotation.interval: Invalid value: \"10m0s\""))
+	})
+
+	It("rejects a weak SECRET_KEY supplied through spec.secret", func() {
+		DeferCleanup(k8sClient.Delete, ctx, th.CreateSecret(
+			types.NamespacedName{Namespace: namespace, Name: SecretName},
+			map[string][]byte{"HorizonSecretKey": []byte(strings.Repeat("ab", 30))},
+		))
+		horizonSpec := GetDefaultHorizonSpec()
+		raw := map[string]any{
+			"apiVersion": "horizon.openstack.org/v1beta1",
+			"kind":       "Horizon",
+			"metadata": map[string]any{
+				"name":      "horizon",
+				"namespace": namespace,
+			},
+			"spec": horizonSpec,
+		}
+		unstructuredObj := &unstructured.Unstructured{Object: raw}
+		_, err := controllerutil.CreateOrPatch(
+			th.Ctx, th.K8sClient, unstructuredObj, func() error { return nil })
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(
+			ContainSubstring("spec.passwordSelectors.secretKey: Invalid value: \"HorizonSecretKey\": SECRET_KEY has too few unique characters"))
+	})
+
+	It("rejects a secondary catalog interface equal to the catalog one", func() {
+		horizonSpec := GetDefaultHorizonSpec()
+		horizonSpec["profile"] = "admin"
+		horizonSpec["endpointInterfaces"] = map[string]any{
+			"secondaryCatalog": "internal",
+		}
+		raw := map[string]any{
+			"apiVersion": "horizon.openstack.org/v1beta1",
+			"kind":       "Horizon",
+			"metadata": map[string]any{
+				"name":      "horizon",
+				"namespace": namespace,
+			},
+			"spec": horizonSpec,
+		}
+		unstructuredObj := &unstructured.Unstructured{Object: raw}
+		_, err := controllerutil.CreateOrPatch(
+			th.Ctx, th.K8sClient, unstructuredObj, func() error { return nil })
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(
+			ContainSubstring("spec.endpointInterfaces.secondaryCatalog: Invalid value: \"internal\": must differ from the internal catalog interface"))
+	})
+
+	It("rejects a region with both a Keystone URL and a KeystoneAPI", func() {
+		horizonSpec := GetDefaultHorizonSpec()
+		horizonSpec["regions"] = []map[string]any{
+			{
+				"name":        "regionTwo",
+				"keystoneURL": "https://keystone.r2.example.com:5000",
+				"keystoneAPI": map[string]any{"name": "keystone"},
+			},
+			{
+				"name":        "regionTwo",
+				"keystoneURL": "keystone.r2.example.com",
+			},
+		}
+		raw := map[string]any{
+			"apiVersion": "horizon.openstack.org/v1beta1",
+			"kind":       "Horizon",
+			"metadata": map[string]any{
+				"name":      "horizon",
+				"namespace": namespace,
+			},
+			"spec": horizonSpec,
+		}
+		unstructuredObj := &unstructured.Unstructured{Object: raw}
+		_, err := controllerutil.CreateOrPatch(
+			th.Ctx, th.K8sClient, unstructuredObj, func() error { return nil })
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(
+			ContainSubstring("spec.regions[0].keystoneAPI: Forbidden: keystoneURL and keystoneAPI are mutually exclusive"))
+		Expect(err.Error()).To(
+			ContainSubstring("spec.regions[1].name: Duplicate value: \"regionTwo\""))
+		Expect(err.Error()).To(
+			ContainSubstring("spec.regions[1].keystoneURL: Invalid value: \"keystone.r2.example.com\": must be an http or https URL"))
+	})
+
+	It("rejects an ingress with a TLS termination not matching the TLS of the pods", func() {
+		horizonSpec := GetDefaultHorizonSpec()
+		horizonSpec["ingress"] = map[string]any{
+			"hostname":       "Dashboard_example.com",
+			"tlsTermination": "reencrypt",
+		}
+		raw := map[string]any{
+			"apiVersion": "horizon.openstack.org/v1beta1",
+			"kind":       "Horizon",
+			"metadata": map[string]any{
+				"name":      "horizon",
+				"namespace": namespace,
+			},
+			"spec": horizonSpec,
+		}
+		unstructuredObj := &unstructured.Unstructured{Object: raw}
+		_, err := controllerutil.CreateOrPatch(
+			th.Ctx, th.K8sClient, unstructuredObj, func() error { return nil })
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(
+			ContainSubstring("spec.ingress.hostname: Invalid value: \"Dashboard_example.com\""))
+		Expect(err.Error()).To(
+			ContainSubstring("spec.ingress.tlsTermination: Invalid value: \"reencrypt\": reencrypt and passthrough terminations require TLS to be enabled on the pods"))
+	})
+
+	It("rejects a passthrough ingress without the Route API", func() {
+		horizonSpec := GetTLSHorizonSpec()
+		horizonSpec["ingress"] = map[string]any{
+			"tlsTermination": "passthrough",
+		}
+		raw := map[string]any{
+			"apiVersion": "horizon.openstack.org/v1beta1",
+			"kind":       "Horizon",
+			"metadata": map[string]any{
+				"name":      "horizon",
+				"namespace": namespace,
+			},
+			"spec": horizonSpec,
+		}
+		unstructuredObj := &unstructured.Unstructured{Object: raw}
+		_, err := controllerutil.CreateOrPatch(
+			th.Ctx, th.K8sClient, unstructuredObj, func() error { return nil })
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(
+			ContainSubstring("spec.ingress.tlsTermination: Invalid value: \"passthrough\": passthrough termination requires an OpenShift Route"))
+	})
+
+	It("rejects a gateway together with an ingress", func() {
+		horizonSpec := GetDefaultHorizonSpec()
+		horizonSpec["ingress"] = map[string]any{}
+		horizonSpec["override"] = map[string]any{
+			"gateway": map[string]any{
+				"parentRef": map[string]any{"name": "public"},
+				"hostnames": []string{"dashboard.example.com", "dashboard.example.com"},
+			},
+		}
+		raw := map[string]any{
+			"apiVersion": "horizon.openstack.org/v1beta1",
+			"kind":       "Horizon",
+			"metadata": map[string]any{
+				"name":      "horizon",
+				"namespace": namespace,
+			},
+			"spec": horizonSpec,
+		}
+		unstructuredObj := &unstructured.Unstructured{Object: raw}
+		_, err := controllerutil.CreateOrPatch(
+			th.Ctx, th.K8sClient, unstructuredObj, func() error { return nil })
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(
+			ContainSubstring("spec.override.gateway: Forbidden: ingress and override.gateway are mutually exclusive"))
+		Expect(err.Error()).To(
+			ContainSubstring("spec.override.gateway.hostnames[1]: Duplicate value: \"dashboard.example.com\""))
+	})
+
+	It("rejects a gateway when TLS is enabled on the pods", func() {
+		horizonSpec := GetTLSHorizonSpec()
+		horizonSpec["override"] = map[string]any{
+			"gateway": map[string]any{
+				"parentRef": map[string]any{"name": "public"},
+			},
+		}
+		raw := map[string]any{
+			"apiVersion": "horizon.openstack.org/v1beta1",
+			"kind":       "Horizon",
+			"metadata": map[string]any{
+				"name":      "horizon",
+				"namespace": namespace,
+			},
+			"spec": horizonSpec,
+		}
+		unstructuredObj := &unstructured.Unstructured{Object: raw}
+		_, err := controllerutil.CreateOrPatch(
+			th.Ctx, th.K8sClient, unstructuredObj, func() error { return nil })
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(
+			ContainSubstring("spec.override.gateway: Forbidden: override.gateway requires TLS to be disabled on the pods"))
+	})
+
+	It("rejects an invalid web root", func() {
+		horizonSpec := GetDefaultHorizonSpec()
+		horizonSpec["webRoot"] = "horizon dashboard"
+		raw := map[string]any{
+			"apiVersion": "horizon.openstack.org/v1beta1",
+			"kind":       "Horizon",
+			"metadata": map[string]any{
+				"name":      "horizon",
+				"namespace": namespace,
+			},
+			"spec": horizonSpec,
+		}
+		unstructuredObj := &unstructured.Unstructured{Object: raw}
+		_, err := controllerutil.CreateOrPatch(
+			th.Ctx, th.K8sClient, unstructuredObj, func() error { return nil })
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(
+			ContainSubstring("spec.webRoot: Invalid value: \"horizon dashboard\": must be / or an absolute URL path"))
+	})
+
+	It("rejects duplicated or invalid additional hostnames", func() {
+		horizonSpec := GetDefaultHorizonSpec()
+		horizonSpec["additionalHostnames"] = []string{"dashboard.example.com", "dashboard.example.com", "https://cloud.example.org"}
+		raw := map[string]any{
+			"apiVersion": "horizon.openstack.org/v1beta1",
+			"kind":       "Horizon",
+			"metadata": map[string]any{
+				"name":      "horizon",
+				"namespace": namespace,
+			},
+			"spec": horizonSpec,
+		}
+		unstructuredObj := &unstructured.Unstructured{Object: raw}
+		_, err := controllerutil.CreateOrPatch(
+			th.Ctx, th.K8sClient, unstructuredObj, func() error { return nil })
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(
+			ContainSubstring("spec.additionalHostnames[1]: Duplicate value: \"dashboard.example.com\""))
+		Expect(err.Error()).To(
+			ContainSubstring("spec.additionalHostnames[2]: Invalid value: \"https://cloud.example.org\""))
+	})
 })